@@ -0,0 +1,134 @@
+package askit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers are pre-defined answers keyed by field path (i.e. Contact.Email).
+// A value is either a scalar or a list of scalars for slice fields.
+type Answers map[string]interface{}
+
+// LoadAnswers reads answers from a YAML or JSON file.
+// Answers can be keyed by field path or nested in the same way as the struct fields.
+//
+//	Name: Jane Doe
+//	Contact.Email: jane.doe@example.com
+//	Server:
+//	  Port: 8080
+//	  Tags: [web, api]
+func LoadAnswers(path string) (Answers, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: %s", path, err)
+	}
+
+	answers := Answers{}
+	flattenAnswers("", m, answers)
+
+	return answers, nil
+}
+
+func flattenAnswers(prefix string, m map[string]interface{}, answers Answers) {
+	for key, val := range m {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if nested, ok := val.(map[string]interface{}); ok {
+			flattenAnswers(key, nested, answers)
+		} else {
+			answers[key] = val
+		}
+	}
+}
+
+// UnansweredError is returned in non-interactive mode when required fields are left without an answer.
+type UnansweredError struct {
+	Fields []string
+}
+
+func (e *UnansweredError) Error() string {
+	return "no answer provided for " + strings.Join(e.Fields, ", ")
+}
+
+// lookupAnswer returns the pre-defined answer for a field if any.
+// Environment variables take precedence over the answers.
+func lookupAnswer(f fieldInfo, opts *Options) (string, bool) {
+	if opts.Env {
+		if val, ok := os.LookupEnv(envName(opts.EnvPrefix, f.Name)); ok {
+			return val, true
+		}
+	}
+
+	val, ok := opts.Answers[f.Name]
+	if !ok || val == nil {
+		return "", false
+	}
+
+	switch v := val.(type) {
+	case []string:
+		return strings.Join(v, f.Sep), true
+	case []interface{}:
+		vals := make([]string, len(v))
+		for i := range v {
+			vals[i] = fmt.Sprint(v[i])
+		}
+		return strings.Join(vals, f.Sep), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// envName converts a field path to an environment variable name (i.e. Contact.LogLevel --> PREFIX_CONTACT_LOG_LEVEL).
+func envName(prefix, path string) string {
+	var b strings.Builder
+
+	if prefix != "" {
+		b.WriteString(strings.ToUpper(prefix))
+		b.WriteByte('_')
+	}
+
+	runes := []rune(path)
+	for i, r := range runes {
+		switch {
+		case r == '.':
+			b.WriteByte('_')
+		case unicode.IsUpper(r) && i > 0 && runes[i-1] != '.' &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	return b.String()
+}
+
+// answerField assigns the pre-defined answer to a field if any.
+func answerField(f fieldInfo, opts *Options) (bool, error) {
+	val, ok := lookupAnswer(f, opts)
+	if !ok {
+		return false, nil
+	}
+
+	v, err := parseValue(f, val)
+	if err != nil {
+		return true, err
+	}
+
+	f.Value.Set(v)
+
+	return true, nil
+}
@@ -0,0 +1,220 @@
+package askit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeAnswers(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestLoadAnswers(t *testing.T) {
+	tests := []struct {
+		name            string
+		filename        string
+		content         string
+		expectedError   string
+		expectedAnswers Answers
+	}{
+		{
+			name:          "InvalidFile",
+			filename:      "answers.yaml",
+			content:       "Name: [",
+			expectedError: "invalid answers file",
+		},
+		{
+			name:     "YAML",
+			filename: "answers.yaml",
+			content: `
+Name: Jane Doe
+Contact.Email: jane.doe@example.com
+Server:
+  Port: 8080
+  Tags: [web, api]
+`,
+			expectedAnswers: Answers{
+				"Name":          "Jane Doe",
+				"Contact.Email": "jane.doe@example.com",
+				"Server.Port":   8080,
+				"Server.Tags":   []interface{}{"web", "api"},
+			},
+		},
+		{
+			name:     "JSON",
+			filename: "answers.json",
+			content:  `{"Name": "Jane Doe", "Contact": {"Email": "jane.doe@example.com"}}`,
+			expectedAnswers: Answers{
+				"Name":          "Jane Doe",
+				"Contact.Email": "jane.doe@example.com",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeAnswers(t, tc.filename, tc.content)
+			answers, err := LoadAnswers(path)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAnswers, answers)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.Nil(t, answers)
+			}
+		})
+	}
+
+	t.Run("NoFile", func(t *testing.T) {
+		answers, err := LoadAnswers(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.Error(t, err)
+		assert.Nil(t, answers)
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		prefix       string
+		path         string
+		expectedName string
+	}{
+		{"", "Name", "NAME"},
+		{"", "Contact.Email", "CONTACT_EMAIL"},
+		{"app", "LogLevel", "APP_LOG_LEVEL"},
+		{"APP", "Server.APIKey", "APP_SERVER_API_KEY"},
+		{"APP", "TLS.CertFile", "APP_TLS_CERT_FILE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, envName(tc.prefix, tc.path))
+		})
+	}
+}
+
+func TestAsk_NonInteractive(t *testing.T) {
+	type config struct {
+		Name    string `ask:"any, your name"`
+		Contact struct {
+			Email string `ask:"email, your email address, required"`
+		}
+		Server struct {
+			Port int      `ask:"port, the server port, required"`
+			Tags []string `ask:"any, the server tags, required"`
+		}
+		Token string `ask:"secret, your access token"`
+		Level string `ask:"any, the log level" default:"info"`
+		Debug bool   `ask:"any, enable debugging"`
+	}
+
+	tests := []struct {
+		name           string
+		env            map[string]string
+		opts           []Option
+		expectedError  string
+		expectedConfig config
+	}{
+		{
+			name: "Unanswered",
+			opts: []Option{
+				WithNonInteractive(),
+				WithAnswers(Answers{"Name": "Jane Doe"}),
+			},
+			expectedError: "no answer provided for Contact.Email, Server.Port, Server.Tags",
+			expectedConfig: config{
+				Token: "access_token",
+			},
+		},
+		{
+			name: "InvalidAnswer",
+			opts: []Option{
+				WithNonInteractive(),
+				WithAnswers(Answers{"Contact.Email": "jane.doe"}),
+			},
+			expectedError: "invalid email address entered for Contact.Email: mail: missing '@' or angle-addr",
+			expectedConfig: config{
+				Token: "access_token",
+			},
+		},
+		{
+			name: "Success",
+			env: map[string]string{
+				"APP_SERVER_PORT": "9090",
+				"APP_TOKEN":       "new_token",
+			},
+			opts: []Option{
+				WithNonInteractive(),
+				WithEnv("APP"),
+				WithAnswers(Answers{
+					"Name":          "Jane Doe",
+					"Contact.Email": "jane.doe@example.com",
+					"Server.Port":   8080,
+					"Server.Tags":   []interface{}{"web", "api"},
+				}),
+			},
+			expectedConfig: config{
+				Name: "Jane Doe",
+				Contact: struct {
+					Email string `ask:"email, your email address, required"`
+				}{
+					Email: "jane.doe@example.com",
+				},
+				Server: struct {
+					Port int      `ask:"port, the server port, required"`
+					Tags []string `ask:"any, the server tags, required"`
+				}{
+					Port: 9090,
+					Tags: []string{"web", "api"},
+				},
+				Token: "new_token",
+				Level: "info",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, val := range tc.env {
+				t.Setenv(name, val)
+			}
+
+			c := config{Token: "access_token"}
+			err := Ask(&c, nil, tc.opts...)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedConfig, c)
+		})
+	}
+}
+
+func TestAsk_Answers(t *testing.T) {
+	s := struct {
+		Name  string `ask:"any, your name"`
+		Email string `ask:"email, your email address, required"`
+	}{}
+
+	asker := &MockAsker{
+		AskMocks: []AskMock{
+			{OutString: "Y"}, {OutString: "jane.doe@example.com"}, // Email
+		},
+	}
+
+	err := Ask(&s, asker, WithAnswers(Answers{"Name": "Jane Doe"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", s.Name)
+	assert.Equal(t, "jane.doe@example.com", s.Email)
+	assert.Equal(t, 2, asker.AskIndex)
+}
@@ -2,20 +2,33 @@
 package askit
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/mail"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gardenbed/charm/internal/rflct"
 )
 
 const (
-	askTag = "ask"
-	sepTag = "sep"
+	askTag     = "ask"
+	sepTag     = "sep"
+	defaultTag = "default"
+
+	requiredOpt = "required"
+	confirmOpt  = "confirm"
 )
 
+// ClearAnswer is the answer for clearing the value of a field.
+// A field is cleared to its zero value, and pointer and slice fields are set to nil.
+// The answer itself can be entered by escaping it with an extra colon (::clear).
+// It also clears a select or multiselect field when entered in a numbered menu.
+const ClearAnswer = ":clear"
+
 // Kind determines the kind of an input.
+// New kinds can be registered using RegisterKind.
 type Kind string
 
 const (
@@ -25,24 +38,273 @@ const (
 	KindEmail Kind = "email"
 	// KindSecret denotes a secret input (password, token, etc.).
 	KindSecret Kind = "secret"
+	// KindSelect denotes a choice of one of the options provided by the options tag.
+	KindSelect Kind = "select"
+	// KindMultiSelect denotes a choice of any number of the options provided by the options tag (for slice fields).
+	KindMultiSelect Kind = "multiselect"
+	// KindURL denotes an absolute URL input.
+	KindURL Kind = "url"
+	// KindHostname denotes a hostname input (RFC 1123).
+	KindHostname Kind = "hostname"
+	// KindPort denotes a port number input (1-65535).
+	KindPort Kind = "port"
+	// KindPath denotes a path input on the local filesystem.
+	// A leading ~ and environment variables are expanded.
+	// The must-exist, dir, and file options of the ask tag constrain the path.
+	KindPath Kind = "path"
+	// KindPathExists denotes an input for a path that exists on the local filesystem.
+	KindPathExists Kind = "path-exists"
+	// KindSemver denotes a semantic version input.
+	KindSemver Kind = "semver"
+	// KindIP denotes an IPv4 or IPv6 address input.
+	KindIP Kind = "ip"
+	// KindCIDR denotes an IP address range input in CIDR notation.
+	KindCIDR Kind = "cidr"
+	// KindEditor denotes a long text input edited in the editor set by $VISUAL or $EDITOR.
+	// If no editor is available, the input is read the same as the multiline kind.
+	KindEditor Kind = "editor"
+	// KindMultiline denotes a text input of multiple lines ended by a terminator line.
+	KindMultiline Kind = "multiline"
 )
 
 // Asker is the interface for getting inputs.
+// An Asker can optionally implement the Selector interface for presenting options natively.
 type Asker interface {
 	Output(string)
 	Ask(string) (string, error)
 	AskSecret(string) (string, error)
 }
 
-func Ask(s interface{}, asker Asker) error {
-	v, err := rflct.IsStructPtr(s)
+// Options configures the behavior of Ask.
+type Options struct {
+	// MaxAttempts is the maximum number of attempts for entering a valid value for a field.
+	// If an invalid value is entered, the error is shown and the value is asked for again.
+	// Zero or a negative value means unlimited attempts.
+	MaxAttempts int
+
+	// Streamlined asks for values directly without asking whether or not to enter a value first.
+	// The current or default value of a field is kept if nothing is entered.
+	Streamlined bool
+
+	// NonInteractive disables asking for values.
+	// Fields are only assigned from the answers, environment variables, and default values.
+	// If a required field with no value is left without an answer, an UnansweredError is returned.
+	NonInteractive bool
+
+	// Answers are pre-defined answers keyed by field path (i.e. Contact.Email).
+	// A field with an answer is not asked for.
+	Answers Answers
+
+	// Env enables reading answers from environment variables.
+	// The name of the variable for a field is the field path in upper snake case
+	// prefixed by EnvPrefix (i.e. Contact.Email --> PREFIX_CONTACT_EMAIL).
+	Env       bool
+	EnvPrefix string
+
+	// Review shows a summary of the changed fields at the end and asks for confirmation before assigning them.
+	Review bool
+
+	// Predicate decides whether or not a field should be asked for.
+	// It is evaluated after the ask-if tag of the field.
+	Predicate Predicate
+
+	// PromptTimeout is the maximum duration to wait for each prompt to be answered.
+	// If a prompt times out, a TimeoutError is returned unless TimeoutFallback is enabled.
+	// Zero means no timeout.
+	PromptTimeout time.Duration
+
+	// TimeoutFallback keeps the current or default value of a field when its prompt times out.
+	// A required field with no value still fails with a TimeoutError.
+	TimeoutFallback bool
+
+	// Renderer renders the headers, prompts, and messages shown to the user.
+	// It can be used for changing the styles or translating the messages.
+	Renderer Renderer
+}
+
+// Option sets an option for Ask.
+type Option func(*Options)
+
+// WithMaxAttempts sets the maximum number of attempts for entering a valid value for a field.
+// The default is 3 attempts.
+func WithMaxAttempts(n int) Option {
+	return func(o *Options) {
+		o.MaxAttempts = n
+	}
+}
+
+// WithStreamlined asks for values directly without asking whether or not to enter a value first.
+func WithStreamlined() Option {
+	return func(o *Options) {
+		o.Streamlined = true
+	}
+}
+
+// WithNonInteractive disables asking for values.
+// Fields are only assigned from the answers and environment variables.
+func WithNonInteractive() Option {
+	return func(o *Options) {
+		o.NonInteractive = true
+	}
+}
+
+// WithAnswers sets pre-defined answers keyed by field path.
+// Answers can be read from a YAML or JSON file using LoadAnswers.
+func WithAnswers(answers Answers) Option {
+	return func(o *Options) {
+		o.Answers = answers
+	}
+}
+
+// WithEnv enables reading answers from environment variables with the given prefix.
+func WithEnv(prefix string) Option {
+	return func(o *Options) {
+		o.Env = true
+		o.EnvPrefix = prefix
+	}
+}
+
+// WithReview shows a summary of the changed fields at the end and asks for confirmation before assigning them.
+// If the changes are not confirmed, ErrDiscarded is returned.
+func WithReview() Option {
+	return func(o *Options) {
+		o.Review = true
+	}
+}
+
+// WithPredicate sets a predicate for deciding whether or not a field should be asked for.
+func WithPredicate(p Predicate) Option {
+	return func(o *Options) {
+		o.Predicate = p
+	}
+}
+
+// WithPromptTimeout sets the maximum duration to wait for each prompt to be answered.
+// If fallback is true, a field keeps its current or default value when its prompt times out.
+// Otherwise, a TimeoutError is returned.
+func WithPromptTimeout(timeout time.Duration, fallback bool) Option {
+	return func(o *Options) {
+		o.PromptTimeout = timeout
+		o.TimeoutFallback = fallback
+	}
+}
+
+// WithRenderer sets the renderer for the headers, prompts, and messages shown to the user.
+func WithRenderer(r Renderer) Option {
+	return func(o *Options) {
+		o.Renderer = r
+	}
+}
+
+func newOptions(opts ...Option) *Options {
+	o := &Options{
+		MaxAttempts: 3,
+		Renderer:    defaultRenderer,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// inputError is an error caused by an invalid input that can be corrected by asking again.
+type inputError struct {
+	err error
+}
+
+func (e *inputError) Error() string {
+	return e.err.Error()
+}
+
+// Ask accepts the pointer to a struct type and an Asker.
+// For those struct fields that have the ask tag, it will ask for new values and assign them to the fields.
+// Fields with pre-defined answers are not asked for.
+// Fields whose ask-if condition or predicate is not met are skipped.
+//
+// New values are collected in a copy of the struct and only assigned to the fields once all of them are entered.
+// If an error occurs, the struct is left unchanged.
+func Ask(s interface{}, asker Asker, opts ...Option) error {
+	return AskContext(context.Background(), s, asker, opts...)
+}
+
+// AskContext is the same as Ask, but it stops asking for values once the context is cancelled.
+// If the Asker implements the ContextAsker interface, the context is passed to it for each prompt.
+func AskContext(ctx context.Context, s interface{}, asker Asker, opts ...Option) error {
+	orig, err := rflct.IsStructPtr(s)
 	if err != nil {
 		return err
 	}
 
-	return iterateOnFields("", v, func(f fieldInfo) error {
-		return askForField(f, asker)
+	// A shallow copy is sufficient since pointers and slices are replaced rather than modified
+	v := reflect.New(orig.Type()).Elem()
+	v.Set(orig)
+
+	o := newOptions(opts...)
+	unanswered := []string{}
+	entered := map[*section]bool{}
+
+	if asker != nil {
+		asker = newContextAsker(ctx, asker, o.PromptTimeout)
+	}
+
+	err = iterateOnFields("", v, func(f fieldInfo) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if ok, err := shouldAsk(v, f, o); !ok || err != nil {
+			return err
+		}
+
+		// Earlier answers can be referenced in descriptions
+		f.Description = interpolate(v, f.Description)
+
+		if ok, err := answerField(f, o); ok || err != nil {
+			return err
+		}
+
+		if o.NonInteractive {
+			if err := applyDefault(f); err != nil {
+				return err
+			}
+
+			if f.Required && f.Value.IsZero() {
+				unanswered = append(unanswered, f.Name)
+			}
+
+			return nil
+		}
+
+		// Fields in a skipped section keep their current or default values
+		if ok, err := enterSection(f.Section, asker, o, entered); !ok || err != nil {
+			if err != nil {
+				return handleTimeout(f, asker, o, err)
+			}
+			return applyDefault(f)
+		}
+
+		return handleTimeout(f, asker, o, askForField(f, asker, o))
 	})
+
+	if err != nil {
+		return err
+	}
+
+	if len(unanswered) > 0 {
+		return &UnansweredError{Fields: unanswered}
+	}
+
+	if o.Review && !o.NonInteractive {
+		if err := reviewChanges(orig, v, asker, o); err != nil {
+			return err
+		}
+	}
+
+	orig.Set(v)
+
+	return nil
 }
 
 type fieldInfo struct {
@@ -51,19 +313,47 @@ type fieldInfo struct {
 	Kind        Kind
 	Description string
 	Sep         string
+	Options     []string
+	Condition   string
+	Default     string
+	Required    bool
+	Confirm     bool
+	List        bool
+	MustExist   bool
+	Dir         bool
+	File        bool
+	Policy      *Policy
+	Section     *section
+	LastError   error
 }
 
 func iterateOnFields(prefix string, vStruct reflect.Value, handle func(fieldInfo) error) error {
+	return iterateOnSection(prefix, nil, vStruct, handle)
+}
+
+func iterateOnSection(prefix string, sec *section, vStruct reflect.Value, handle func(fieldInfo) error) error {
+	order, err := sortFields(prefix, vStruct)
+	if err != nil {
+		return err
+	}
+
 	// Iterate over struct fields
-	for i := 0; i < vStruct.NumField(); i++ {
+	for _, i := range order {
 		v := vStruct.Field(i)        // reflect.Value       --> vField.Kind(), vField.Type().Name(), vField.Type().Kind(), vField.Interface()
 		t := v.Type()                // reflect.Type        --> t.Kind(), t.PkgPath(), t.Name(), t.NumField()
 		f := vStruct.Type().Field(i) // reflect.StructField --> f.Name, f.Type.Name(), f.Type.Kind(), f.Tag.Get(tag)
 
 		// Recursively, iterate on nested structs
 		if rflct.IsNestedStruct(t) {
-			newPrefix := prefix + f.Name
-			if err := iterateOnFields(newPrefix, v, handle); err != nil {
+			newPrefix := f.Name
+			if prefix != "" {
+				newPrefix = prefix + "." + f.Name
+			}
+			newSec := sec
+			if val, ok := f.Tag.Lookup(sectionTag); ok {
+				newSec = parseSection(newPrefix, val, sec)
+			}
+			if err := iterateOnSection(newPrefix, newSec, v, handle); err != nil {
 				return err
 			}
 			continue
@@ -93,9 +383,12 @@ func iterateOnFields(prefix string, vStruct reflect.Value, handle func(fieldInfo
 		}
 
 		fi := fieldInfo{
-			Value: v,
-			Name:  name,
-			Sep:   sep,
+			Value:     v,
+			Name:      name,
+			Sep:       sep,
+			Condition: f.Tag.Get(askIfTag),
+			Default:   f.Tag.Get(defaultTag),
+			Section:   sec,
 		}
 
 		if isKindSupported(subs[0]) {
@@ -106,6 +399,37 @@ func iterateOnFields(prefix string, vStruct reflect.Value, handle func(fieldInfo
 			fi.Description = strings.TrimSpace(subs[1])
 		}
 
+		for i := 2; i < len(subs); i++ {
+			switch strings.TrimSpace(subs[i]) {
+			case requiredOpt:
+				fi.Required = true
+			case confirmOpt:
+				fi.Confirm = true
+			case listOpt:
+				fi.List = t.Kind() == reflect.Slice
+			case mustExistOpt:
+				fi.MustExist = true
+			case dirOpt:
+				fi.Dir = true
+			case fileOpt:
+				fi.File = true
+			}
+		}
+
+		// `policy:"..."`
+		if val := f.Tag.Get(policyTag); val != "" {
+			p, err := parsePolicy(val)
+			if err != nil {
+				return fmt.Errorf("invalid %s tag for %s: %s", policyTag, name, err)
+			}
+			fi.Policy = p
+		}
+
+		// `options:"..."`
+		if fi.Kind == KindSelect || fi.Kind == KindMultiSelect {
+			fi.Options = parseOptions(f.Tag.Get(optionsTag))
+		}
+
 		if err := handle(fi); err != nil {
 			return err
 		}
@@ -116,63 +440,436 @@ func iterateOnFields(prefix string, vStruct reflect.Value, handle func(fieldInfo
 
 func isKindSupported(kind string) bool {
 	switch Kind(kind) {
-	case KindAny, KindEmail, KindSecret:
+	case KindSelect, KindMultiSelect:
 		return true
 	default:
-		return false
+		_, ok := lookupKind(Kind(kind))
+		return ok
 	}
 }
 
-func askForField(f fieldInfo, asker Asker) error {
-	// Print in bold style
-	asker.Output(fmt.Sprintf("\033[1m%s\033[0m", f.Name))
+func askForField(f fieldInfo, asker Asker, opts *Options) error {
+	// A FieldAsker presents the field itself
+	_, isFieldAsker := asFieldAsker(asker)
 
-	if !f.Value.IsZero() {
-		if f.Kind == KindSecret {
-			asker.Output("  • Current value: *******")
-		} else {
-			asker.Output(fmt.Sprintf("  • Current value: %v", f.Value.Interface()))
+	if !isFieldAsker {
+		asker.Output(opts.render(MessageHeader, f.Name))
+	}
+
+	// A required field with no value cannot be skipped
+	if !opts.Streamlined && !isFieldAsker && (!f.Required || !f.Value.IsZero()) {
+		if !f.Value.IsZero() {
+			asker.Output(opts.render(MessageCurrentValue, formatValue(f)))
+		}
+
+		ans, err := asker.Ask(opts.render(MessageEnterGate))
+		if err != nil {
+			return err
+		}
+
+		if !opts.isYes(ans) {
+			return applyDefault(f)
 		}
 	}
 
-	ans, err := asker.Ask("  • Would you like to enter a value [Y]?")
+	for attempt := 1; ; attempt++ {
+		v, err := askForValue(f, asker, opts)
+		if err == nil {
+			f.Value.Set(v)
+			return nil
+		}
+
+		// Only invalid inputs can be retried
+		var ie *inputError
+		if !errors.As(err, &ie) || (opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts) {
+			return err
+		}
+
+		// The error is passed along with the field to a FieldAsker
+		if isFieldAsker {
+			f.LastError = err
+			continue
+		}
+
+		asker.Output(opts.render(MessageTryAgain, err))
+	}
+}
+
+// askForValue asks for a new value for a field.
+// The new value is validated and returned without being assigned to the field.
+// If nothing is entered, the current or default value is returned.
+func askForValue(f fieldInfo, asker Asker, opts *Options) (reflect.Value, error) {
+	var val string
+	var err error
+
+	if fa, ok := asFieldAsker(asker); ok {
+		val, err = fa.AskField(newField(f))
+
+		// An empty answer keeps the current or default value, so selecting no options is explicit
+		if err == nil && f.Kind == KindMultiSelect {
+			switch {
+			case val == ClearAnswer:
+				val = ""
+			case val == "" && !f.Value.IsZero():
+				return f.Value, nil
+			case val == "":
+				val = f.Default
+			}
+
+			return parseValue(f, val)
+		}
+	} else if f.Kind == KindSelect || f.Kind == KindMultiSelect {
+		val, err = askForChoice(f, asker, opts)
+		if errors.Is(err, errCleared) {
+			return clearValue(f, asker, opts)
+		}
+	} else if f.List {
+		return askForList(f, asker, opts)
+	} else if f.Kind == KindEditor || f.Kind == KindMultiline {
+		val, err = askForMultiline(f, asker, opts)
+	} else {
+		val, err = askForText(f, asker, opts)
+	}
+
 	if err != nil {
-		return err
+		return reflect.Value{}, err
 	}
 
-	if ans == "" || strings.ToUpper(ans[:1]) != "Y" {
-		return nil
+	if f.Kind != KindSelect && f.Kind != KindMultiSelect {
+		switch val {
+		case ClearAnswer:
+			return clearValue(f, asker, opts)
+		case ":" + ClearAnswer:
+			val = ClearAnswer
+		}
+	}
+
+	if val == "" && f.Kind != KindMultiSelect {
+		switch {
+		case !f.Value.IsZero():
+			return f.Value, nil
+		case f.Default != "":
+			val = f.Default
+		case f.Required:
+			return reflect.Value{}, &inputError{
+				errors.New(opts.render(MessageValueRequired, f.Name)),
+			}
+		default:
+			// An optional field left empty is not validated
+			return f.Value, nil
+		}
+	}
+
+	return parseValue(f, val)
+}
+
+// clearValue returns the zero value of a field.
+// A required field cannot be cleared, and clearing a secret must be confirmed.
+func clearValue(f fieldInfo, asker Asker, opts *Options) (reflect.Value, error) {
+	if f.Required {
+		return reflect.Value{}, &inputError{
+			errors.New(opts.render(MessageValueRequired, f.Name)),
+		}
+	}
+
+	if isMasked(f.Kind) {
+		ans, err := asker.Ask(opts.render(MessageClearConfirm, f.Name))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if !opts.isYes(ans) {
+			return f.Value, nil
+		}
+	}
+
+	return reflect.Zero(f.Value.Type()), nil
+}
+
+func askForText(f fieldInfo, asker Asker, opts *Options) (string, error) {
+	spec, _ := lookupKind(f.Kind)
+
+	description := f.Description
+	if description == "" {
+		description = spec.Hint
 	}
 
 	// Create the user prompt
 	var prompt string
-	if f.Description == "" {
-		prompt = "  Enter a new value:"
+	if description == "" {
+		prompt = opts.render(MessageEnterValue)
 	} else {
-		prompt = fmt.Sprintf("  • Enter a new value (%s):", f.Description)
+		prompt = opts.render(MessageEnterValueHint, description)
 	}
 
-	// Determine which ask function to use
-	askFunc := asker.Ask
-	if f.Kind == KindSecret {
-		askFunc = asker.AskSecret
+	// Show the value kept if nothing is entered
+	if !f.Value.IsZero() {
+		prompt = fmt.Sprintf("%s [%s]", prompt, formatValue(f))
+	} else if f.Default != "" {
+		prompt = fmt.Sprintf("%s [%s]", prompt, f.Default)
 	}
 
+	askFunc := askFuncFor(f, asker)
+
 	val, err := askFunc(prompt)
+	if err != nil || val == "" || val == ClearAnswer || !f.Confirm {
+		return val, err
+	}
+
+	// Ask for the new value again and compare the entries
+	again, err := askFunc(opts.render(MessageConfirmValue))
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if again != val {
+		return "", &inputError{
+			errors.New(opts.render(MessageValuesMismatch, f.Name)),
+		}
+	}
+
+	return val, nil
+}
+
+func askForChoice(f fieldInfo, asker Asker, opts *Options) (string, error) {
+	if len(f.Options) == 0 {
+		return "", fmt.Errorf("no options provided for %s", f.Name)
+	}
+
+	// Create the user prompt
+	var prompt string
+	if f.Description == "" {
+		prompt = opts.render(MessageSelectOption)
+	} else {
+		prompt = opts.render(MessageSelectOptionHint, f.Description)
+	}
+
+	if f.Kind == KindMultiSelect {
+		if f.Value.Kind() != reflect.Slice {
+			return "", fmt.Errorf("multiselect is not supported for non-slice field %s", f.Name)
+		}
+
+		// Determine the options already selected
+		defaults := []int{}
+		if f.Value.Len() > 0 {
+			for i := 0; i < f.Value.Len(); i++ {
+				if j := indexOf(f.Options, fmt.Sprint(f.Value.Index(i).Interface())); j >= 0 {
+					defaults = append(defaults, j)
+				}
+			}
+		} else if f.Default != "" {
+			for _, val := range strings.Split(f.Default, f.Sep) {
+				if j := indexOf(f.Options, val); j >= 0 {
+					defaults = append(defaults, j)
+				}
+			}
+		}
+
+		var indices []int
+		var err error
+
+		if s, ok := asSelector(asker); ok {
+			indices, err = s.MultiSelect(prompt, f.Options, defaults)
+		} else {
+			indices, err = multiSelectByNumber(asker, opts, prompt, f.Options, defaults, true)
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		vals := make([]string, len(indices))
+		for i, j := range indices {
+			vals[i] = f.Options[j]
+		}
+
+		return strings.Join(vals, f.Sep), nil
+	}
+
+	// Determine the option already selected
+	def := -1
+	if !f.Value.IsZero() {
+		def = indexOf(f.Options, fmt.Sprint(reflect.Indirect(f.Value).Interface()))
+	} else if f.Default != "" {
+		def = indexOf(f.Options, f.Default)
+	}
+
+	var i int
+	var err error
+
+	if s, ok := asSelector(asker); ok {
+		i, err = s.Select(prompt, f.Options, def)
+	} else {
+		i, err = selectByNumber(asker, opts, prompt, f.Options, def, true)
 	}
 
-	if _, err := rflct.SetValue(f.Value, f.Sep, val); err != nil {
-		return fmt.Errorf("invalid value entered for %s: %s", f.Name, err)
+	if err != nil {
+		return "", err
+	}
+
+	return f.Options[i], nil
+}
+
+// askFuncFor determines which ask function to use for a field.
+func askFuncFor(f fieldInfo, asker Asker) func(string) (string, error) {
+	if isMasked(f.Kind) {
+		return asker.AskSecret
+	}
+
+	if p, ok := asker.(PathAsker); ok && f.Kind == KindPath {
+		return p.AskPath
+	}
+
+	return asker.Ask
+}
+
+// parseValue parses and validates a value for a field.
+// The field itself is not modified.
+func parseValue(f fieldInfo, val string) (reflect.Value, error) {
+	v := reflect.New(f.Value.Type()).Elem()
+	v.Set(f.Value)
+
+	// No option selected for a multiselect field
+	if f.Kind == KindMultiSelect && val == "" {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		return v, nil
+	}
+
+	// Only the provided options can be chosen
+	if f.Kind == KindSelect || f.Kind == KindMultiSelect {
+		vals := []string{val}
+		if f.Kind == KindMultiSelect {
+			vals = strings.Split(val, f.Sep)
+		}
+
+		for _, v := range vals {
+			if indexOf(f.Options, v) < 0 {
+				return reflect.Value{}, &inputError{
+					fmt.Errorf("invalid option entered for %s: %s", f.Name, v),
+				}
+			}
+		}
+	}
+
+	// Normalize and validate the value (every element of a slice separately)
+	if spec, ok := lookupKind(f.Kind); ok && (spec.Normalize != nil || spec.Validate != nil) {
+		vals := []string{val}
+		if f.Value.Kind() == reflect.Slice {
+			vals = strings.Split(val, f.Sep)
+		}
+
+		for i := range vals {
+			if spec.Normalize != nil {
+				vals[i] = spec.Normalize(vals[i])
+			}
+
+			if spec.Validate != nil {
+				if err := spec.Validate(vals[i]); err != nil {
+					hint := spec.Hint
+					if hint == "" {
+						hint = "value"
+					}
+
+					return reflect.Value{}, &inputError{
+						fmt.Errorf("invalid %s entered for %s: %s", hint, f.Name, err),
+					}
+				}
+			}
+
+			if f.Kind == KindPath {
+				if err := checkPath(f, vals[i]); err != nil {
+					return reflect.Value{}, &inputError{
+						fmt.Errorf("invalid path entered for %s: %s", f.Name, err),
+					}
+				}
+			}
+		}
+
+		val = strings.Join(vals, f.Sep)
+	}
+
+	if f.Policy != nil {
+		if err := f.Policy.Check(val); err != nil {
+			return reflect.Value{}, &inputError{
+				fmt.Errorf("the value entered for %s %s", f.Name, err),
+			}
+		}
 	}
 
-	switch f.Kind {
-	case KindEmail:
-		if _, err := mail.ParseAddress(val); err != nil {
-			return fmt.Errorf("invalid email address entered for %s: %s", f.Name, err)
+	if _, err := rflct.SetValue(v, f.Sep, val); err != nil {
+		// Secret values must not be included in errors
+		if isMasked(f.Kind) {
+			return reflect.Value{}, &inputError{
+				fmt.Errorf("invalid value entered for %s", f.Name),
+			}
 		}
+
+		return reflect.Value{}, &inputError{
+			fmt.Errorf("invalid value entered for %s: %s", f.Name, err),
+		}
+	}
+
+	return v, nil
+}
+
+// applyDefault assigns the default value to a field with no value.
+func applyDefault(f fieldInfo) error {
+	if f.Default == "" || !f.Value.IsZero() {
+		return nil
 	}
 
+	v, err := parseValue(f, f.Default)
+	if err != nil {
+		return fmt.Errorf("invalid default value for %s: %s", f.Name, err)
+	}
+
+	f.Value.Set(v)
+
 	return nil
 }
+
+// formatValue formats the current value of a field for showing to the user.
+func formatValue(f fieldInfo) string {
+	if isMasked(f.Kind) {
+		return "*******"
+	}
+
+	v := reflect.Indirect(f.Value)
+	if v.Kind() == reflect.Slice {
+		vals := make([]string, v.Len())
+		for i := range vals {
+			vals[i] = stringify(v.Index(i))
+		}
+		return strings.Join(vals, f.Sep)
+	}
+
+	return stringify(v)
+}
+
+// stringify formats a value using its String method if it has one (i.e. url.URL).
+func stringify(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func indexOf(options []string, val string) int {
+	for i, opt := range options {
+		if opt == val {
+			return i
+		}
+	}
+
+	return -1
+}
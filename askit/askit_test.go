@@ -262,6 +262,71 @@ func TestAsk(t *testing.T) {
 }
 
 func TestIterateOnFields(t *testing.T) {
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		s := struct {
+			Password string `ask:"secret, your password" policy:"min=eight"`
+		}{}
+
+		v := reflect.ValueOf(&s).Elem()
+		err := iterateOnFields("", v, func(f fieldInfo) error {
+			return nil
+		})
+
+		assert.EqualError(t, err, "invalid policy tag for Password: invalid rule: min=eight")
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		s := struct {
+			Password string `ask:"secret, your password, required, confirm" policy:"min=12,classes=3"`
+		}{}
+
+		v := reflect.ValueOf(&s).Elem()
+		err := iterateOnFields("", v, func(f fieldInfo) error {
+			assert.True(t, f.Required)
+			assert.True(t, f.Confirm)
+			assert.Equal(t, &Policy{MinLength: 12, MinClasses: 3}, f.Policy)
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("PathOptions", func(t *testing.T) {
+		s := struct {
+			Config string `ask:"path, the config file, must-exist, file"`
+			Data   string `ask:"path, the data directory, dir"`
+		}{}
+
+		constraints := [][3]bool{}
+
+		v := reflect.ValueOf(&s).Elem()
+		err := iterateOnFields("", v, func(f fieldInfo) error {
+			assert.Equal(t, KindPath, f.Kind)
+			constraints = append(constraints, [3]bool{f.MustExist, f.Dir, f.File})
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][3]bool{{true, false, true}, {false, true, false}}, constraints)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		s := struct {
+			Hosts []string `ask:"hostname, the hosts, list"`
+			Host  string   `ask:"hostname, the host, list"`
+		}{}
+
+		lists := []bool{}
+		v := reflect.ValueOf(&s).Elem()
+		err := iterateOnFields("", v, func(f fieldInfo) error {
+			lists = append(lists, f.List)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, lists)
+	})
+
 	t.Run("HandleFails", func(t *testing.T) {
 		v := reflect.ValueOf(&rflct.Flags{}).Elem()
 		err := iterateOnFields("", v, func(f fieldInfo) error {
@@ -430,6 +495,26 @@ func TestIsKindSupported(t *testing.T) {
 			kind:         "secret",
 			expectedBool: true,
 		},
+		{
+			name:         "Select",
+			kind:         "select",
+			expectedBool: true,
+		},
+		{
+			name:         "MultiSelect",
+			kind:         "multiselect",
+			expectedBool: true,
+		},
+		{
+			name:         "URL",
+			kind:         "url",
+			expectedBool: true,
+		},
+		{
+			name:         "CIDR",
+			kind:         "cidr",
+			expectedBool: true,
+		},
 		{
 			name:         "Unsupported",
 			kind:         "unsupported",
@@ -444,6 +529,177 @@ func TestIsKindSupported(t *testing.T) {
 	}
 }
 
+func TestAsk_Select(t *testing.T) {
+	type spec struct {
+		Env     string   `ask:"select, the environment" options:"dev|staging|prod"`
+		Regions []string `ask:"multiselect, the regions" options:"us|eu|asia"`
+		Zones   []string `ask:"multiselect, the zones" options:"a|b|c"`
+	}
+
+	tests := []struct {
+		name          string
+		s             *spec
+		asker         Asker
+		expectedError string
+		expected      *spec
+	}{
+		{
+			name: "NumberedMenu",
+			s:    &spec{Env: "staging", Zones: []string{"a"}},
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"}, {OutString: ""}, // Env
+					{OutString: "Y"}, {OutString: "3,1"}, // Regions
+					{OutString: "Y"}, {OutString: "x"}, {OutString: "2"}, // Zones
+				},
+			},
+			expected: &spec{Env: "staging", Regions: []string{"asia", "us"}, Zones: []string{"b"}},
+		},
+		{
+			name: "ClearMenu",
+			s:    &spec{Env: "staging", Regions: []string{"us"}, Zones: []string{"a"}},
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"}, {OutString: ":clear"}, // Env
+					{OutString: "Y"}, {OutString: ":clear"}, // Regions
+					{OutString: "Y"}, {OutString: ""}, // Zones
+				},
+			},
+			expected: &spec{Env: "", Regions: nil, Zones: []string{"a"}},
+		},
+		{
+			name: "Selector",
+			s:    &spec{Zones: []string{"a"}},
+			asker: &mockSelector{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "Y"}, // Env
+						{OutString: "Y"}, // Regions
+						{OutString: "Y"}, // Zones
+					},
+				},
+				SelectOutIndex:        2,
+				MultiSelectOutIndices: []int{},
+			},
+			expected: &spec{Env: "prod", Regions: []string{}, Zones: []string{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Ask(tc.s, tc.asker)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expected, tc.s)
+		})
+	}
+}
+
+func TestAsk_Optional(t *testing.T) {
+	type spec struct {
+		Site     string `ask:"url, website"`
+		Password string `ask:"secret, your password" policy:"min=8"`
+	}
+
+	tests := []struct {
+		name     string
+		asker    *MockAsker
+		opts     []Option
+		expected *spec
+	}{
+		{
+			name: "Streamlined",
+			asker: &MockAsker{
+				AskMocks:       []AskMock{{OutString: ""}},
+				AskSecretMocks: []AskSecretMock{{OutString: ""}},
+			},
+			opts:     []Option{WithStreamlined(), WithMaxAttempts(1)},
+			expected: &spec{},
+		},
+		{
+			name: "Gate",
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"}, {OutString: ""}, // Site
+					{OutString: "Y"}, // Password
+				},
+				AskSecretMocks: []AskSecretMock{{OutString: ""}},
+			},
+			opts:     []Option{WithMaxAttempts(1)},
+			expected: &spec{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := new(spec)
+			err := Ask(s, tc.asker, tc.opts...)
+
+			// An optional field left empty is not validated
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, s)
+			assert.Equal(t, len(tc.asker.AskMocks), tc.asker.AskIndex)
+			assert.Equal(t, len(tc.asker.AskSecretMocks), tc.asker.AskSecretIndex)
+		})
+	}
+}
+
+func TestAskForChoice(t *testing.T) {
+	env := "dev"
+
+	tests := []struct {
+		name          string
+		f             fieldInfo
+		asker         Asker
+		expectedError string
+	}{
+		{
+			name: "NoOptions",
+			f: fieldInfo{
+				Value: reflect.ValueOf(&env).Elem(),
+				Name:  "Env",
+				Kind:  KindSelect,
+			},
+			asker:         &MockAsker{},
+			expectedError: "no options provided for Env",
+		},
+		{
+			name: "MultiSelectNonSlice",
+			f: fieldInfo{
+				Value:   reflect.ValueOf(&env).Elem(),
+				Name:    "Env",
+				Kind:    KindMultiSelect,
+				Options: []string{"dev", "prod"},
+			},
+			asker:         &MockAsker{},
+			expectedError: "multiselect is not supported for non-slice field Env",
+		},
+		{
+			name: "SelectFails",
+			f: fieldInfo{
+				Value:   reflect.ValueOf(&env).Elem(),
+				Name:    "Env",
+				Kind:    KindSelect,
+				Options: []string{"dev", "prod"},
+			},
+			asker:         &mockSelector{SelectOutError: errors.New("io error")},
+			expectedError: "io error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := askForChoice(tc.f, tc.asker, &Options{})
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
 func TestAskForField(t *testing.T) {
 	id := 69
 	token := "secret"
@@ -578,13 +834,573 @@ func TestAskForField(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := askForField(tc.f, tc.asker)
+			err := askForField(tc.f, tc.asker, &Options{MaxAttempts: 1})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestAskForField_Retry(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		asker         *MockAsker
+		expectedError string
+		expectedEmail string
+	}{
+		{
+			name:        "Exhausted",
+			maxAttempts: 2,
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"},
+					{OutString: "invalid_email"},
+					{OutString: "another_invalid_email"},
+				},
+			},
+			expectedError: "invalid email address entered for Email: mail: missing '@' or angle-addr",
+			expectedEmail: "jane.doe@example.com",
+		},
+		{
+			name:        "AskFails",
+			maxAttempts: 3,
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"},
+					{OutString: "invalid_email"},
+					{OutError: errors.New("io error")},
+				},
+			},
+			expectedError: "io error",
+			expectedEmail: "jane.doe@example.com",
+		},
+		{
+			name:        "Unlimited",
+			maxAttempts: 0,
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"},
+					{OutString: "invalid_email"},
+					{OutString: "invalid_email"},
+					{OutString: "invalid_email"},
+					{OutString: "invalid_email"},
+					{OutString: "john.doe@example.com"},
+				},
+			},
+			expectedEmail: "john.doe@example.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			email := "jane.doe@example.com"
+			f := fieldInfo{
+				Value: reflect.ValueOf(&email).Elem(),
+				Name:  "Email",
+				Kind:  KindEmail,
+				Sep:   ",",
+			}
+
+			err := askForField(f, tc.asker, &Options{MaxAttempts: tc.maxAttempts})
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
 			} else {
 				assert.EqualError(t, err, tc.expectedError)
 			}
+
+			assert.Equal(t, tc.expectedEmail, email)
+			assert.Equal(t, len(tc.asker.AskMocks), tc.asker.AskIndex)
 		})
 	}
 }
+
+func TestAskForField_Streamlined(t *testing.T) {
+	tests := []struct {
+		name            string
+		port            int
+		defaultValue    string
+		required        bool
+		asker           *MockAsker
+		expectedError   string
+		expectedPort    int
+		expectedPrompts []string
+	}{
+		{
+			name:          "AskFails",
+			asker:         &MockAsker{AskMocks: []AskMock{{OutError: errors.New("io error")}}},
+			expectedError: "io error",
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port):",
+			},
+		},
+		{
+			name:         "KeepCurrentValue",
+			port:         8080,
+			defaultValue: "9090",
+			asker:        &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			expectedPort: 8080,
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port): [8080]",
+			},
+		},
+		{
+			name:         "AcceptDefault",
+			defaultValue: "9090",
+			asker:        &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			expectedPort: 9090,
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port): [9090]",
+			},
+		},
+		{
+			name:     "Required",
+			required: true,
+			asker: &MockAsker{AskMocks: []AskMock{
+				{OutString: ""},
+				{OutString: "443"},
+			}},
+			expectedPort: 443,
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port):",
+				"  • Enter a new value (the server port):",
+			},
+		},
+		{
+			name:          "RequiredExhausted",
+			required:      true,
+			asker:         &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			expectedError: "a value is required for Port",
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port):",
+			},
+		},
+		{
+			name:         "NewValue",
+			port:         8080,
+			asker:        &MockAsker{AskMocks: []AskMock{{OutString: "443"}}},
+			expectedPort: 443,
+			expectedPrompts: []string{
+				"  • Enter a new value (the server port): [8080]",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			port := tc.port
+			f := fieldInfo{
+				Value:       reflect.ValueOf(&port).Elem(),
+				Name:        "Port",
+				Kind:        KindPort,
+				Description: "the server port",
+				Sep:         ",",
+				Default:     tc.defaultValue,
+				Required:    tc.required,
+			}
+
+			maxAttempts := len(tc.asker.AskMocks)
+			err := askForField(f, tc.asker, &Options{MaxAttempts: maxAttempts, Streamlined: true})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			prompts := []string{}
+			for _, m := range tc.asker.AskMocks[:tc.asker.AskIndex] {
+				prompts = append(prompts, m.InPrompt)
+			}
+
+			assert.Equal(t, tc.expectedPort, port)
+			assert.Equal(t, tc.expectedPrompts, prompts)
+		})
+	}
+}
+
+func TestAskForField_Required(t *testing.T) {
+	token := ""
+	f := fieldInfo{
+		Value:       reflect.ValueOf(&token).Elem(),
+		Name:        "Token",
+		Kind:        KindSecret,
+		Description: "your token",
+		Sep:         ",",
+		Required:    true,
+	}
+
+	// No confirmation is asked for a required field with no value
+	asker := &MockAsker{
+		AskSecretMocks: []AskSecretMock{{OutString: "access_token"}},
+	}
+
+	err := askForField(f, asker, &Options{MaxAttempts: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token", token)
+	assert.Equal(t, 0, asker.AskIndex)
+
+	// The current value is masked
+	asker = &MockAsker{
+		AskMocks:       []AskMock{{OutString: "Y"}},
+		AskSecretMocks: []AskSecretMock{{OutString: ""}},
+	}
+
+	err = askForField(f, asker, &Options{MaxAttempts: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token", token)
+	assert.Equal(t, "  • Enter a new value (your token): [*******]", asker.AskSecretMocks[0].InPrompt)
+}
+
+func TestAskForField_Confirm(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *Policy
+		asker         *MockAsker
+		expectedError string
+		expectedToken string
+	}{
+		{
+			name: "ConfirmFails",
+			asker: &MockAsker{
+				AskSecretMocks: []AskSecretMock{
+					{OutString: "new_token"},
+					{OutError: errors.New("io error")},
+				},
+			},
+			expectedError: "io error",
+		},
+		{
+			name: "Mismatch",
+			asker: &MockAsker{
+				AskSecretMocks: []AskSecretMock{
+					{OutString: "new_token"},
+					{OutString: "new_tokn"},
+				},
+			},
+			expectedError: "the values entered for Token do not match",
+		},
+		{
+			name:   "WeakSecret",
+			policy: &Policy{MinLength: 12},
+			asker: &MockAsker{
+				AskSecretMocks: []AskSecretMock{
+					{OutString: "new_token"},
+					{OutString: "new_token"},
+				},
+			},
+			expectedError: "the value entered for Token must be at least 12 characters long",
+		},
+		{
+			name:   "Success",
+			policy: &Policy{MinLength: 12},
+			asker: &MockAsker{
+				AskSecretMocks: []AskSecretMock{
+					{OutString: "new_long_token"},
+					{OutString: "new_long_token"},
+				},
+			},
+			expectedToken: "new_long_token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := ""
+			f := fieldInfo{
+				Value:       reflect.ValueOf(&token).Elem(),
+				Name:        "Token",
+				Kind:        KindSecret,
+				Description: "your token",
+				Sep:         ",",
+				Required:    true,
+				Confirm:     true,
+				Policy:      tc.policy,
+			}
+
+			err := askForField(f, tc.asker, &Options{MaxAttempts: 1})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedToken, token)
+			assert.Equal(t, "  • Confirm the new value:", tc.asker.AskSecretMocks[1].InPrompt)
+		})
+	}
+}
+
+func TestAskForField_Clear(t *testing.T) {
+	type config struct {
+		Name    string   `ask:"any, your name"`
+		Port    *int     `ask:"port, the port number"`
+		Tags    []string `ask:"any, your tags"`
+		Token   string   `ask:"secret, your token, confirm"`
+		Email   string   `ask:"email, your email address, required"`
+		Literal string   `ask:"any, a literal value"`
+	}
+
+	port := 8080
+
+	tests := []struct {
+		name           string
+		field          string
+		asker          *MockAsker
+		expectedError  string
+		expectedConfig config
+	}{
+		{
+			name:           "ZeroValue",
+			field:          "Name",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ClearAnswer}}},
+			expectedConfig: config{Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:           "NilPointer",
+			field:          "Port",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ClearAnswer}}},
+			expectedConfig: config{Name: "Jane", Tags: []string{"web"}, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:           "NilSlice",
+			field:          "Tags",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ClearAnswer}}},
+			expectedConfig: config{Name: "Jane", Port: &port, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:  "SecretConfirmFails",
+			field: "Token",
+			asker: &MockAsker{
+				AskMocks:       []AskMock{{OutError: errors.New("io error")}},
+				AskSecretMocks: []AskSecretMock{{OutString: ClearAnswer}},
+			},
+			expectedError:  "io error",
+			expectedConfig: config{Name: "Jane", Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:  "SecretKept",
+			field: "Token",
+			asker: &MockAsker{
+				AskMocks:       []AskMock{{OutString: ""}},
+				AskSecretMocks: []AskSecretMock{{OutString: ClearAnswer}},
+			},
+			expectedConfig: config{Name: "Jane", Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:  "SecretCleared",
+			field: "Token",
+			asker: &MockAsker{
+				AskMocks:       []AskMock{{OutString: "y"}},
+				AskSecretMocks: []AskSecretMock{{OutString: ClearAnswer}},
+			},
+			expectedConfig: config{Name: "Jane", Port: &port, Tags: []string{"web"}, Email: "jane@example.com"},
+		},
+		{
+			name:           "Required",
+			field:          "Email",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ClearAnswer}}},
+			expectedError:  "a value is required for Email",
+			expectedConfig: config{Name: "Jane", Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com"},
+		},
+		{
+			name:           "Escaped",
+			field:          "Literal",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ":" + ClearAnswer}}},
+			expectedConfig: config{Name: "Jane", Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com", Literal: ClearAnswer},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := config{Name: "Jane", Port: &port, Tags: []string{"web"}, Token: "token", Email: "jane@example.com"}
+
+			var err error
+			_ = iterateOnFields("", reflect.ValueOf(&c).Elem(), func(f fieldInfo) error {
+				if f.Name == tc.field {
+					err = askForField(f, tc.asker, &Options{MaxAttempts: 1, Streamlined: true})
+				}
+				return nil
+			})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedConfig, c)
+		})
+	}
+
+	t.Run("ConfirmPrompt", func(t *testing.T) {
+		token := "token"
+		f := fieldInfo{Value: reflect.ValueOf(&token).Elem(), Name: "Token", Kind: KindSecret, Sep: ","}
+		asker := &MockAsker{AskMocks: []AskMock{{OutString: "n"}}}
+
+		v, err := clearValue(f, asker, &Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", v.Interface())
+		assert.Equal(t, "  • Are you sure you want to clear Token [y/N]?", asker.AskMocks[0].InPrompt)
+	})
+}
+
+func TestApplyDefault(t *testing.T) {
+	tests := []struct {
+		name          string
+		port          int
+		defaultValue  string
+		expectedError string
+		expectedPort  int
+	}{
+		{"NoDefault", 0, "", "", 0},
+		{"CurrentValue", 8080, "9090", "", 8080},
+		{"Default", 0, "9090", "", 9090},
+		{"InvalidDefault", 0, "http", "invalid default value for Port: invalid port number entered for Port: port must be a number between 1 and 65535", 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			port := tc.port
+			f := fieldInfo{
+				Value:   reflect.ValueOf(&port).Elem(),
+				Name:    "Port",
+				Kind:    KindPort,
+				Sep:     ",",
+				Default: tc.defaultValue,
+			}
+
+			err := applyDefault(f)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedPort, port)
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	name := "Jane Doe"
+	token := "access_token"
+	level := ptr.String("info")
+	tags := []string{"web", "api"}
+
+	tests := []struct {
+		name          string
+		f             fieldInfo
+		expectedValue string
+	}{
+		{"Value", fieldInfo{Value: reflect.ValueOf(&name).Elem(), Kind: KindAny, Sep: ","}, "Jane Doe"},
+		{"Secret", fieldInfo{Value: reflect.ValueOf(&token).Elem(), Kind: KindSecret, Sep: ","}, "*******"},
+		{"Pointer", fieldInfo{Value: reflect.ValueOf(&level).Elem(), Kind: KindAny, Sep: ","}, "info"},
+		{"Slice", fieldInfo{Value: reflect.ValueOf(&tags).Elem(), Kind: KindAny, Sep: "|"}, "web|api"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedValue, formatValue(tc.f))
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	port := 8080
+	email := "jane.doe@example.com"
+	names := []string{"alice"}
+
+	tests := []struct {
+		name          string
+		f             fieldInfo
+		val           string
+		expectedValue interface{}
+		expectedError string
+	}{
+		{
+			name:          "SetValueFails",
+			f:             fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "Port", Kind: KindAny, Sep: ","},
+			val:           "https",
+			expectedError: `invalid value entered for Port: strconv.ParseInt: parsing "https": invalid syntax`,
+		},
+		{
+			name:          "InvalidOption",
+			f:             fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "Port", Kind: KindSelect, Sep: ",", Options: []string{"80", "443"}},
+			val:           "8443",
+			expectedError: "invalid option entered for Port: 8443",
+		},
+		{
+			name:          "InvalidMultiSelectOption",
+			f:             fieldInfo{Value: reflect.ValueOf(&names).Elem(), Name: "Names", Kind: KindMultiSelect, Sep: ",", Options: []string{"alice", "bob"}},
+			val:           "alice,eve",
+			expectedError: "invalid option entered for Names: eve",
+		},
+		{
+			name:          "InvalidEmail",
+			f:             fieldInfo{Value: reflect.ValueOf(&email).Elem(), Name: "Email", Kind: KindEmail, Sep: ","},
+			val:           "invalid_email",
+			expectedError: "invalid email address entered for Email: mail: missing '@' or angle-addr",
+		},
+		{
+			name:          "InvalidSecret",
+			f:             fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "PIN", Kind: KindSecret, Sep: ","},
+			val:           "secret_pin",
+			expectedError: "invalid value entered for PIN",
+		},
+		{
+			name:          "InvalidSliceElement",
+			f:             fieldInfo{Value: reflect.ValueOf(&names).Elem(), Name: "Hosts", Kind: KindHostname, Sep: ","},
+			val:           "example.com,-invalid",
+			expectedError: `invalid hostname entered for Hosts: invalid hostname label: "-invalid"`,
+		},
+		{
+			name:          "NormalizedSlice",
+			f:             fieldInfo{Value: reflect.ValueOf(&names).Elem(), Name: "Hosts", Kind: KindHostname, Sep: ","},
+			val:           " Example.COM. ,localhost",
+			expectedValue: []string{"example.com", "localhost"},
+		},
+		{
+			name:          "EmptyMultiSelect",
+			f:             fieldInfo{Value: reflect.ValueOf(&names).Elem(), Name: "Names", Kind: KindMultiSelect, Sep: ","},
+			val:           "",
+			expectedValue: []string{},
+		},
+		{
+			name:          "Success",
+			f:             fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "Port", Kind: KindAny, Sep: ","},
+			val:           "9090",
+			expectedValue: 9090,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := parseValue(tc.f, tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedValue, v.Interface())
+			} else {
+				var ie *inputError
+				assert.ErrorAs(t, err, &ie)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+
+	// The fields must not be modified
+	assert.Equal(t, 8080, port)
+	assert.Equal(t, "jane.doe@example.com", email)
+	assert.Equal(t, []string{"alice"}, names)
+}
@@ -0,0 +1,357 @@
+// Package askittest provides a scripted askit.Asker for testing the code that asks for inputs using askit.
+// The prompts are expected in the order they are scripted, and every prompt is answered with a canned answer or error.
+package askittest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrUnexpectedPrompt is returned for a prompt that does not match the next expectation.
+var ErrUnexpectedPrompt = errors.New("unexpected prompt")
+
+// TestingT is the subset of testing.T used by Asker.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Matcher matches a prompt.
+type Matcher interface {
+	Match(prompt string) bool
+	String() string
+}
+
+type containsMatcher string
+
+func (m containsMatcher) Match(prompt string) bool {
+	return strings.Contains(prompt, string(m))
+}
+
+func (m containsMatcher) String() string {
+	return fmt.Sprintf("containing %q", string(m))
+}
+
+// Contains creates a Matcher for the prompts containing a substring.
+func Contains(substr string) Matcher {
+	return containsMatcher(substr)
+}
+
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m regexpMatcher) Match(prompt string) bool {
+	return m.re.MatchString(prompt)
+}
+
+func (m regexpMatcher) String() string {
+	return fmt.Sprintf("matching /%s/", m.re)
+}
+
+// Matches creates a Matcher for the prompts matching a regular expression.
+// It panics if the expression cannot be parsed.
+func Matches(expr string) Matcher {
+	return regexpMatcher{
+		re: regexp.MustCompile(expr),
+	}
+}
+
+// Method is the Asker method expected to be called for a prompt.
+type Method string
+
+// Asker methods.
+const (
+	MethodAsk         Method = "Ask"
+	MethodAskSecret   Method = "AskSecret"
+	MethodSelect      Method = "Select"
+	MethodMultiSelect Method = "MultiSelect"
+)
+
+// Expectation is a prompt expected by an Asker and its canned answer.
+type Expectation struct {
+	method  Method
+	matcher Matcher
+	answer  string
+	choices []string
+	chosen  bool
+	err     error
+	times   int
+	calls   int
+}
+
+// Answer sets the answer for an Ask or AskSecret prompt.
+func (e *Expectation) Answer(ans string) *Expectation {
+	e.answer = ans
+	return e
+}
+
+// Choose sets the options chosen for a Select or MultiSelect prompt.
+// If no option is chosen, the default options are kept.
+// Choosing no option explicitly for a MultiSelect prompt is possible using ChooseNone.
+func (e *Expectation) Choose(options ...string) *Expectation {
+	e.choices = options
+	e.chosen = len(options) > 0
+	return e
+}
+
+// ChooseNone sets no option to be chosen for a MultiSelect prompt.
+func (e *Expectation) ChooseNone() *Expectation {
+	e.choices = []string{}
+	e.chosen = true
+	return e
+}
+
+// Error sets the error returned for the prompt.
+func (e *Expectation) Error(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// Times sets the number of consecutive prompts expected (the default is one).
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+func (e *Expectation) String() string {
+	return fmt.Sprintf("%s %s", e.method, e.matcher)
+}
+
+// call is a prompt received by an Asker.
+type call struct {
+	method Method
+	prompt string
+}
+
+func (c call) String() string {
+	return fmt.Sprintf("%s %q", c.method, c.prompt)
+}
+
+// Asker is a scripted askit.Asker.
+// It also implements the askit.Selector interface, so options can be chosen by name.
+// Asker is safe for concurrent use.
+type Asker struct {
+	mu           sync.Mutex
+	t            TestingT
+	expectations []*Expectation
+	next         int
+	calls        []call
+	outputs      []string
+}
+
+// New creates a new scripted Asker.
+// Unexpected prompts are reported to t.
+func New(t TestingT) *Asker {
+	return &Asker{
+		t: t,
+	}
+}
+
+func (a *Asker) expect(method Method, matcher Matcher) *Expectation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e := &Expectation{
+		method:  method,
+		matcher: matcher,
+		times:   1,
+	}
+
+	a.expectations = append(a.expectations, e)
+
+	return e
+}
+
+// ExpectAsk expects an Ask prompt.
+func (a *Asker) ExpectAsk(matcher Matcher) *Expectation {
+	return a.expect(MethodAsk, matcher)
+}
+
+// ExpectSecret expects an AskSecret prompt.
+func (a *Asker) ExpectSecret(matcher Matcher) *Expectation {
+	return a.expect(MethodAskSecret, matcher)
+}
+
+// ExpectSelect expects a Select prompt.
+func (a *Asker) ExpectSelect(matcher Matcher) *Expectation {
+	return a.expect(MethodSelect, matcher)
+}
+
+// ExpectMultiSelect expects a MultiSelect prompt.
+func (a *Asker) ExpectMultiSelect(matcher Matcher) *Expectation {
+	return a.expect(MethodMultiSelect, matcher)
+}
+
+// match matches a prompt against the next expectation.
+func (a *Asker) match(method Method, prompt string) (*Expectation, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c := call{method, prompt}
+
+	if a.next < len(a.expectations) {
+		if e := a.expectations[a.next]; e.method == method && e.matcher.Match(prompt) {
+			a.calls = append(a.calls, c)
+			if e.calls++; e.calls >= e.times {
+				a.next++
+			}
+			return e, nil
+		}
+	}
+
+	a.t.Helper()
+	a.t.Errorf("askittest: unexpected prompt %s\n%s", c, a.diff(c))
+
+	return nil, fmt.Errorf("%w: %s", ErrUnexpectedPrompt, c)
+}
+
+// diff describes the prompts received so far and the unexpected prompt against the expectations.
+func (a *Asker) diff(unexpected call) string {
+	var b strings.Builder
+	b.WriteString("--- expected\n+++ actual\n")
+
+	for _, c := range a.calls {
+		fmt.Fprintf(&b, "  %s\n", c)
+	}
+
+	if a.next < len(a.expectations) {
+		fmt.Fprintf(&b, "- %s\n", a.expectations[a.next])
+	} else {
+		b.WriteString("- (no more prompts)\n")
+	}
+
+	fmt.Fprintf(&b, "+ %s\n", unexpected)
+
+	for _, e := range a.expectations[min(a.next+1, len(a.expectations)):] {
+		fmt.Fprintf(&b, "- %s\n", e)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Output records a message.
+func (a *Asker) Output(message string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.outputs = append(a.outputs, message)
+}
+
+// Outputs returns the messages output so far.
+func (a *Asker) Outputs() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]string{}, a.outputs...)
+}
+
+// Ask answers an Ask prompt.
+func (a *Asker) Ask(prompt string) (string, error) {
+	e, err := a.match(MethodAsk, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return e.answer, e.err
+}
+
+// AskSecret answers an AskSecret prompt.
+func (a *Asker) AskSecret(prompt string) (string, error) {
+	e, err := a.match(MethodAskSecret, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return e.answer, e.err
+}
+
+// Select answers a Select prompt with the index of the chosen option.
+// If no option is chosen, the default index is returned.
+func (a *Asker) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	e, err := a.match(MethodSelect, prompt)
+	if err != nil {
+		return -1, err
+	}
+
+	if e.err != nil {
+		return -1, e.err
+	}
+
+	if !e.chosen {
+		if defaultIndex < 0 {
+			a.t.Helper()
+			a.t.Errorf("askittest: no option chosen for %s and no default option", e)
+			return -1, errors.New("no option chosen")
+		}
+		return defaultIndex, nil
+	}
+
+	indices, err := a.indicesOf(e, options)
+	if err != nil {
+		return -1, err
+	}
+
+	return indices[0], nil
+}
+
+// MultiSelect answers a MultiSelect prompt with the indices of the chosen options.
+// If no option is chosen, the default indices are returned.
+func (a *Asker) MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error) {
+	e, err := a.match(MethodMultiSelect, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	if !e.chosen {
+		return defaultIndices, nil
+	}
+
+	return a.indicesOf(e, options)
+}
+
+func (a *Asker) indicesOf(e *Expectation, options []string) ([]int, error) {
+	indices := make([]int, len(e.choices))
+
+	for i, choice := range e.choices {
+		indices[i] = -1
+		for j, opt := range options {
+			if opt == choice {
+				indices[i] = j
+				break
+			}
+		}
+
+		if indices[i] < 0 {
+			a.t.Helper()
+			a.t.Errorf("askittest: option %q chosen for %s is not one of %q", choice, e, options)
+			return nil, fmt.Errorf("invalid option: %s", choice)
+		}
+	}
+
+	return indices, nil
+}
+
+// AssertExpectations reports every expected prompt that has not been received.
+// It returns true if all expectations are met.
+func (a *Asker) AssertExpectations() bool {
+	a.t.Helper()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ok := true
+	for _, e := range a.expectations[a.next:] {
+		a.t.Errorf("askittest: expected prompt %s (received %d of %d)", e, e.calls, e.times)
+		ok = false
+	}
+
+	return ok
+}
@@ -0,0 +1,218 @@
+package askittest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/charm/askit"
+)
+
+// mockT records the errors reported by an Asker.
+type mockT struct {
+	Errors []string
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.Errors = append(m.Errors, fmt.Sprintf(format, args...))
+}
+
+var (
+	_ askit.Asker    = (*Asker)(nil)
+	_ askit.Selector = (*Asker)(nil)
+)
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		name           string
+		m              Matcher
+		prompt         string
+		expectedMatch  bool
+		expectedString string
+	}{
+		{"Contains", Contains("email"), "Enter your email:", true, `containing "email"`},
+		{"NotContains", Contains("name"), "Enter your email:", false, `containing "name"`},
+		{"Matches", Matches(`(?i)^enter .+:$`), "Enter your email:", true, "matching /(?i)^enter .+:$/"},
+		{"NotMatches", Matches(`^Select`), "Enter your email:", false, "matching /^Select/"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMatch, tc.m.Match(tc.prompt))
+			assert.Equal(t, tc.expectedString, tc.m.String())
+		})
+	}
+}
+
+func TestAsker(t *testing.T) {
+	mt := new(mockT)
+	a := New(mt)
+
+	a.ExpectAsk(Contains("name")).Answer("Jane Doe")
+	a.ExpectSecret(Matches(`token`)).Answer("secret")
+	a.ExpectAsk(Contains("retry")).Error(errors.New("io error")).Times(2)
+
+	a.Output("Hello")
+
+	ans, err := a.Ask("Enter your name:")
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", ans)
+
+	ans, err = a.AskSecret("Enter your token:")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", ans)
+
+	_, err = a.Ask("Please retry:")
+	assert.EqualError(t, err, "io error")
+
+	assert.False(t, a.AssertExpectations())
+	assert.Equal(t, []string{`askittest: expected prompt Ask containing "retry" (received 1 of 2)`}, mt.Errors)
+
+	_, err = a.Ask("Please retry:")
+	assert.EqualError(t, err, "io error")
+
+	assert.True(t, a.AssertExpectations())
+	assert.Equal(t, []string{"Hello"}, a.Outputs())
+}
+
+func TestAsker_Unexpected(t *testing.T) {
+	t.Run("WrongPrompt", func(t *testing.T) {
+		mt := new(mockT)
+		a := New(mt)
+
+		a.ExpectAsk(Contains("name")).Answer("Jane Doe")
+		a.ExpectAsk(Contains("phone")).Answer("555-0100")
+		a.ExpectAsk(Contains("email")).Answer("jane@example.com")
+
+		_, err := a.Ask("Enter your name:")
+		assert.NoError(t, err)
+
+		_, err = a.Ask("Enter your email:")
+		assert.ErrorIs(t, err, ErrUnexpectedPrompt)
+		assert.EqualError(t, err, `unexpected prompt: Ask "Enter your email:"`)
+
+		assert.Equal(t, []string{
+			`askittest: unexpected prompt Ask "Enter your email:"` + "\n" +
+				"--- expected\n" +
+				"+++ actual\n" +
+				`  Ask "Enter your name:"` + "\n" +
+				`- Ask containing "phone"` + "\n" +
+				`+ Ask "Enter your email:"` + "\n" +
+				`- Ask containing "email"`,
+		}, mt.Errors)
+	})
+
+	t.Run("WrongMethod", func(t *testing.T) {
+		mt := new(mockT)
+		a := New(mt)
+
+		a.ExpectAsk(Contains("token")).Answer("secret")
+
+		_, err := a.AskSecret("Enter your token:")
+		assert.ErrorIs(t, err, ErrUnexpectedPrompt)
+		assert.Len(t, mt.Errors, 1)
+		assert.Contains(t, mt.Errors[0], `- Ask containing "token"`)
+		assert.Contains(t, mt.Errors[0], `+ AskSecret "Enter your token:"`)
+	})
+
+	t.Run("NoMorePrompts", func(t *testing.T) {
+		mt := new(mockT)
+		a := New(mt)
+
+		_, err := a.Select("Select:", []string{"dev"}, 0)
+		assert.ErrorIs(t, err, ErrUnexpectedPrompt)
+
+		_, err = a.MultiSelect("Select:", []string{"dev"}, nil)
+		assert.ErrorIs(t, err, ErrUnexpectedPrompt)
+
+		assert.Len(t, mt.Errors, 2)
+		assert.Contains(t, mt.Errors[0], "- (no more prompts)\n+ Select \"Select:\"")
+	})
+}
+
+func TestAsker_Select(t *testing.T) {
+	options := []string{"dev", "staging", "prod"}
+
+	mt := new(mockT)
+	a := New(mt)
+
+	a.ExpectSelect(Contains("env")).Choose("prod")
+	a.ExpectSelect(Contains("env"))
+	a.ExpectSelect(Contains("env"))
+	a.ExpectSelect(Contains("env")).Choose("test")
+	a.ExpectSelect(Contains("env")).Error(errors.New("io error"))
+	a.ExpectMultiSelect(Contains("envs")).Choose("prod", "dev")
+	a.ExpectMultiSelect(Contains("envs"))
+	a.ExpectMultiSelect(Contains("envs")).ChooseNone()
+	a.ExpectMultiSelect(Contains("envs")).Choose("test")
+	a.ExpectMultiSelect(Contains("envs")).Error(errors.New("io error"))
+
+	i, err := a.Select("Select an env:", options, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+
+	i, err = a.Select("Select an env:", options, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	_, err = a.Select("Select an env:", options, -1)
+	assert.EqualError(t, err, "no option chosen")
+
+	_, err = a.Select("Select an env:", options, 0)
+	assert.EqualError(t, err, "invalid option: test")
+
+	_, err = a.Select("Select an env:", options, 0)
+	assert.EqualError(t, err, "io error")
+
+	indices, err := a.MultiSelect("Select envs:", options, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, indices)
+
+	indices, err = a.MultiSelect("Select envs:", options, []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, indices)
+
+	indices, err = a.MultiSelect("Select envs:", options, []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, indices)
+
+	_, err = a.MultiSelect("Select envs:", options, nil)
+	assert.EqualError(t, err, "invalid option: test")
+
+	_, err = a.MultiSelect("Select envs:", options, nil)
+	assert.EqualError(t, err, "io error")
+
+	assert.True(t, a.AssertExpectations())
+	assert.Equal(t, []string{
+		`askittest: no option chosen for Select containing "env" and no default option`,
+		`askittest: option "test" chosen for Select containing "env" is not one of ["dev" "staging" "prod"]`,
+		`askittest: option "test" chosen for MultiSelect containing "envs" is not one of ["dev" "staging" "prod"]`,
+	}, mt.Errors)
+}
+
+func TestAsker_Ask(t *testing.T) {
+	type config struct {
+		Name  string   `ask:"any, your name"`
+		Token string   `ask:"secret, your access token"`
+		Env   string   `ask:"select, the environment" options:"dev|prod"`
+		Tags  []string `ask:"multiselect, the tags" options:"web|api|db"`
+	}
+
+	a := New(t)
+	a.ExpectAsk(Contains("your name")).Answer("Jane Doe")
+	a.ExpectSecret(Contains("your access token")).Answer("token")
+	a.ExpectSelect(Contains("the environment")).Choose("prod")
+	a.ExpectMultiSelect(Contains("the tags")).Choose("web", "db")
+
+	c := config{}
+	err := askit.Ask(&c, a, askit.WithStreamlined())
+
+	assert.NoError(t, err)
+	assert.Equal(t, config{Name: "Jane Doe", Token: "token", Env: "prod", Tags: []string{"web", "db"}}, c)
+	assert.True(t, a.AssertExpectations())
+	assert.Contains(t, a.Outputs(), "\033[1mName\033[0m")
+}
@@ -0,0 +1,136 @@
+package askit
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const askIfTag = "ask-if"
+
+// placeholderRE matches a reference to another field in a description (i.e. {Contact.Email}).
+var placeholderRE = regexp.MustCompile(`\{([A-Za-z_][0-9A-Za-z_]*(?:\.[A-Za-z_][0-9A-Za-z_]*)*)\}`)
+
+// Predicate decides whether or not a field should be asked for.
+// It receives the path of the field (i.e. TLS.CertPath).
+type Predicate func(path string) bool
+
+// condition is a parsed ask-if tag.
+//
+//	Path          the field has a non-zero value
+//	!Path         the field has a zero value
+//	Path=a|b      the field value is one of the values
+//	Path!=a|b     the field value is none of the values
+type condition struct {
+	path   string
+	negate bool
+	values []string
+}
+
+func parseCondition(val string) condition {
+	var c condition
+	val = strings.TrimSpace(val)
+
+	if i := strings.Index(val, "!="); i >= 0 {
+		c.path, c.negate = val[:i], true
+		c.values = strings.Split(val[i+2:], "|")
+	} else if i := strings.Index(val, "="); i >= 0 {
+		c.path = val[:i]
+		c.values = strings.Split(val[i+1:], "|")
+	} else if strings.HasPrefix(val, "!") {
+		c.path, c.negate = val[1:], true
+	} else {
+		c.path = val
+	}
+
+	c.path = strings.TrimSpace(c.path)
+	for i := range c.values {
+		c.values[i] = strings.TrimSpace(c.values[i])
+	}
+
+	return c
+}
+
+// evaluate evaluates the condition against the current values of the root struct.
+func (c condition) evaluate(root reflect.Value) (bool, error) {
+	v, _, ok := lookupPath(root, c.path)
+	if !ok {
+		return false, fmt.Errorf("unknown field: %s", c.path)
+	}
+
+	var result bool
+
+	if c.values == nil {
+		result = !v.IsZero()
+	} else {
+		v = reflect.Indirect(v)
+		if v.Kind() == reflect.Slice {
+			for i := 0; i < v.Len() && !result; i++ {
+				result = indexOf(c.values, fmt.Sprint(v.Index(i).Interface())) >= 0
+			}
+		} else if v.IsValid() {
+			result = indexOf(c.values, fmt.Sprint(v.Interface())) >= 0
+		}
+	}
+
+	return result != c.negate, nil
+}
+
+// shouldAsk determines whether or not a field should be asked for based on its ask-if tag and the predicate.
+func shouldAsk(root reflect.Value, f fieldInfo, opts *Options) (bool, error) {
+	if f.Condition != "" {
+		ok, err := parseCondition(f.Condition).evaluate(root)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s tag for %s: %s", askIfTag, f.Name, err)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if opts.Predicate != nil {
+		return opts.Predicate(f.Name), nil
+	}
+
+	return true, nil
+}
+
+// lookupPath finds a field in a struct by its path.
+func lookupPath(root reflect.Value, path string) (reflect.Value, reflect.StructField, bool) {
+	v := root
+	var f reflect.StructField
+
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, reflect.StructField{}, false
+		}
+
+		var ok bool
+		if f, ok = v.Type().FieldByName(name); !ok || !f.IsExported() {
+			return reflect.Value{}, reflect.StructField{}, false
+		}
+
+		v = v.FieldByIndex(f.Index)
+	}
+
+	return v, f, true
+}
+
+// interpolate replaces references to other fields in a description with their current values.
+// Values of masked fields are never shown and unknown references are left as they are.
+func interpolate(root reflect.Value, description string) string {
+	return placeholderRE.ReplaceAllStringFunc(description, func(ref string) string {
+		v, f, ok := lookupPath(root, ref[1:len(ref)-1])
+		if !ok {
+			return ref
+		}
+
+		if kind := strings.SplitN(f.Tag.Get(askTag), ",", 2)[0]; isMasked(Kind(kind)) {
+			return "*******"
+		}
+
+		return stringify(v)
+	})
+}
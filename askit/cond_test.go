@@ -0,0 +1,219 @@
+package askit
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/charm/internal/ptr"
+)
+
+type setup struct {
+	Env string `ask:"select, the environment" options:"dev|staging|prod"`
+	TLS struct {
+		Enabled  bool   `ask:"any, enable TLS"`
+		CertPath string `ask:"any, the certificate path for {Host}" ask-if:"TLS.Enabled"`
+	}
+	Host     string   `ask:"hostname, the server hostname"`
+	Token    string   `ask:"secret, the access token"`
+	Replicas *int     `ask:"any, the number of replicas"`
+	Tags     []string `ask:"any, the tags"`
+	Endpoint url.URL  `ask:"url, the endpoint"`
+	internal string
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		val               string
+		expectedCondition condition
+	}{
+		{"TLS.Enabled", condition{path: "TLS.Enabled"}},
+		{" !TLS.Enabled ", condition{path: "TLS.Enabled", negate: true}},
+		{"Env=prod", condition{path: "Env", values: []string{"prod"}}},
+		{"Env = staging | prod", condition{path: "Env", values: []string{"staging", "prod"}}},
+		{"Env!=dev", condition{path: "Env", negate: true, values: []string{"dev"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCondition, parseCondition(tc.val))
+		})
+	}
+}
+
+func TestCondition_Evaluate(t *testing.T) {
+	s := setup{
+		Env:      "staging",
+		Replicas: ptr.Int(3),
+		Tags:     []string{"web", "api"},
+	}
+	s.TLS.Enabled = true
+
+	root := reflect.ValueOf(&s).Elem()
+
+	tests := []struct {
+		val            string
+		expectedResult bool
+		expectedError  string
+	}{
+		{"Unknown", false, "unknown field: Unknown"},
+		{"TLS.Unknown", false, "unknown field: TLS.Unknown"},
+		{"Env.Name", false, "unknown field: Env.Name"},
+		{"internal", false, "unknown field: internal"},
+		{"TLS.Enabled", true, ""},
+		{"!TLS.Enabled", false, ""},
+		{"Host", false, ""},
+		{"!Host", true, ""},
+		{"Env=prod", false, ""},
+		{"Env=staging|prod", true, ""},
+		{"Env!=dev", true, ""},
+		{"Replicas=3", true, ""},
+		{"Tags=api", true, ""},
+		{"Tags=db", false, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			result, err := parseCondition(tc.val).evaluate(root)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	s := setup{
+		Host:     "example.com",
+		Token:    "access_token",
+		Tags:     []string{"web", "api"},
+		Endpoint: url.URL{Scheme: "https", Host: "example.com"},
+	}
+	s.TLS.Enabled = true
+
+	root := reflect.ValueOf(&s).Elem()
+
+	tests := []struct {
+		description         string
+		expectedDescription string
+	}{
+		{"the certificate path", "the certificate path"},
+		{"the certificate path for {Host}", "the certificate path for example.com"},
+		{"TLS enabled: {TLS.Enabled}", "TLS enabled: true"},
+		{"the token {Token}", "the token *******"},
+		{"{Replicas} replicas", " replicas"},
+		{"tags {Tags}", "tags [web api]"},
+		{"at {Endpoint}", "at https://example.com"},
+		{"{Unknown} and {internal}", "{Unknown} and {internal}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDescription, interpolate(root, tc.description))
+		})
+	}
+}
+
+func TestAsk_Conditional(t *testing.T) {
+	type config struct {
+		TLS struct {
+			Enabled  bool   `ask:"any, enable TLS"`
+			CertPath string `ask:"any, the certificate for {Host}" ask-if:"TLS.Enabled"`
+		}
+		Host  string `ask:"any, the hostname" ask-if:"Env=staging|prod"`
+		Env   string `ask:"any, the environment"`
+		Debug bool   `ask:"any, enable debugging" ask-if:"Env!=prod"`
+	}
+
+	tests := []struct {
+		name            string
+		c               config
+		asker           *MockAsker
+		opts            []Option
+		expectedPrompts []string
+	}{
+		{
+			name: "ConditionsNotMet",
+			c:    config{},
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "N"}, // TLS.Enabled
+					{OutString: "N"}, // Env
+					{OutString: "N"}, // Debug
+				},
+			},
+			expectedPrompts: []string{
+				"  • Would you like to enter a value [Y]?",
+				"  • Would you like to enter a value [Y]?",
+				"  • Would you like to enter a value [Y]?",
+			},
+		},
+		{
+			name: "ConditionsMet",
+			c:    config{Host: "example.com", Env: "prod"},
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"}, {OutString: "true"}, // TLS.Enabled
+					{OutString: "Y"}, {OutString: "/etc/cert.pem"}, // TLS.CertPath
+					{OutString: "N"}, // Host
+					{OutString: "N"}, // Env
+				},
+			},
+			expectedPrompts: []string{
+				"  • Would you like to enter a value [Y]?",
+				"  • Enter a new value (enable TLS):",
+				"  • Would you like to enter a value [Y]?",
+				"  • Enter a new value (the certificate for example.com):",
+				"  • Would you like to enter a value [Y]?",
+				"  • Would you like to enter a value [Y]?",
+			},
+		},
+		{
+			name: "Predicate",
+			c:    config{},
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "N"}, // Env
+					{OutString: "N"}, // Debug
+				},
+			},
+			opts: []Option{
+				WithPredicate(func(path string) bool {
+					return path != "TLS.Enabled"
+				}),
+			},
+			expectedPrompts: []string{
+				"  • Would you like to enter a value [Y]?",
+				"  • Would you like to enter a value [Y]?",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Ask(&tc.c, tc.asker, tc.opts...)
+			assert.NoError(t, err)
+
+			prompts := []string{}
+			for _, m := range tc.asker.AskMocks[:tc.asker.AskIndex] {
+				prompts = append(prompts, m.InPrompt)
+			}
+			assert.Equal(t, tc.expectedPrompts, prompts)
+		})
+	}
+
+	t.Run("UnknownField", func(t *testing.T) {
+		c := struct {
+			Host string `ask:"any, the hostname" ask-if:"TLS.Enabled"`
+		}{}
+
+		err := Ask(&c, &MockAsker{})
+		assert.EqualError(t, err, "invalid ask-if tag for Host: unknown field: TLS.Enabled")
+	})
+}
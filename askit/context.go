@@ -0,0 +1,248 @@
+package askit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ContextAsker is an optional interface for an Asker to support cancellation and timeouts.
+// Askers not implementing this interface are still cancelled, but their pending call is abandoned.
+// The next call waits for the abandoned call to return first, and the answer of the abandoned call is discarded.
+type ContextAsker interface {
+	AskContext(context.Context, string) (string, error)
+	AskSecretContext(context.Context, string) (string, error)
+}
+
+// TimeoutError is returned when no value is entered for a field before the prompt timeout.
+type TimeoutError struct {
+	Field   string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("timed out after %s", e.Timeout)
+	}
+	return fmt.Sprintf("timed out waiting for %s after %s", e.Field, e.Timeout)
+}
+
+// contextAsker binds an Asker to a context and a per-prompt timeout.
+type contextAsker struct {
+	asker   Asker
+	ctx     context.Context
+	timeout time.Duration
+
+	// pending is closed once a call abandoned due to cancellation returns.
+	pending chan struct{}
+}
+
+func newContextAsker(ctx context.Context, asker Asker, timeout time.Duration) Asker {
+	// Nothing to cancel
+	if ctx.Done() == nil && timeout <= 0 {
+		return asker
+	}
+
+	return &contextAsker{
+		asker:   asker,
+		ctx:     ctx,
+		timeout: timeout,
+	}
+}
+
+func (c *contextAsker) call(prompt string, secret bool) (string, error) {
+	var askContext func(context.Context) (string, error)
+	if ca, ok := c.asker.(ContextAsker); ok {
+		askContext = func(ctx context.Context) (string, error) {
+			if secret {
+				return ca.AskSecretContext(ctx, prompt)
+			}
+			return ca.AskContext(ctx, prompt)
+		}
+	}
+
+	return c.run(askContext, func() (string, error) {
+		if secret {
+			return c.asker.AskSecret(prompt)
+		}
+		return c.asker.Ask(prompt)
+	})
+}
+
+// run runs an ask function until the context is cancelled or the prompt times out.
+// If askContext is not nil, the context is passed to it instead.
+// Otherwise, the call is abandoned on cancellation, and the next call waits for it to return first,
+// so the Asker is never called concurrently and the answer of the abandoned call is discarded.
+func (c *contextAsker) run(askContext func(context.Context) (string, error), ask func() (string, error)) (string, error) {
+	if askContext != nil {
+		return c.withTimeout(askContext)
+	}
+
+	// Waiting for the abandoned call has its own timeout, so the prompt is given the full timeout once asked
+	if c.pending != nil {
+		if _, err := c.withTimeout(func(ctx context.Context) (string, error) {
+			select {
+			case <-c.pending:
+				c.pending = nil
+				return "", nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	return c.withTimeout(func(ctx context.Context) (string, error) {
+		type result struct {
+			ans string
+			err error
+		}
+
+		ch := make(chan result, 1)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			var r result
+			r.ans, r.err = ask()
+			ch <- r
+		}()
+
+		select {
+		case r := <-ch:
+			return r.ans, r.err
+		case <-ctx.Done():
+			c.pending = done
+			return "", ctx.Err()
+		}
+	})
+}
+
+// withTimeout calls a function with the context bound to the prompt timeout.
+func (c *contextAsker) withTimeout(f func(context.Context) (string, error)) (string, error) {
+	ctx := c.ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	ans, err := f(ctx)
+
+	// Distinguish the prompt timeout from the cancellation of the parent context
+	if errors.Is(err, context.DeadlineExceeded) && c.ctx.Err() == nil {
+		return "", &TimeoutError{Timeout: c.timeout}
+	}
+
+	return ans, err
+}
+
+func (c *contextAsker) Output(message string) {
+	c.asker.Output(message)
+}
+
+func (c *contextAsker) Ask(prompt string) (string, error) {
+	return c.call(prompt, false)
+}
+
+func (c *contextAsker) AskSecret(prompt string) (string, error) {
+	return c.call(prompt, true)
+}
+
+// selectsByNumber determines whether or not a numbered menu is presented instead of the underlying Selector.
+func (c *contextAsker) selectsByNumber() bool {
+	_, ok := asSelector(c.asker)
+	return !ok || c.timeout > 0
+}
+
+// Select delegates to the underlying Selector if there is no prompt timeout.
+// Otherwise, a numbered menu is presented, so the prompt can time out.
+func (c *contextAsker) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	if s, ok := c.asker.(Selector); ok && c.timeout <= 0 {
+		if err := c.ctx.Err(); err != nil {
+			return -1, err
+		}
+		return s.Select(prompt, options, defaultIndex)
+	}
+
+	return selectByNumber(c, nil, prompt, options, defaultIndex, false)
+}
+
+// MultiSelect delegates to the underlying Selector if there is no prompt timeout.
+// Otherwise, a numbered menu is presented, so the prompt can time out.
+func (c *contextAsker) MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error) {
+	if s, ok := c.asker.(Selector); ok && c.timeout <= 0 {
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+		return s.MultiSelect(prompt, options, defaultIndices)
+	}
+
+	return multiSelectByNumber(c, nil, prompt, options, defaultIndices, false)
+}
+
+// Edit delegates to the underlying Editor if there is no prompt timeout.
+// Otherwise, ErrNoEditor is returned, so the text is read line by line and the prompts can time out.
+func (c *contextAsker) Edit(text string) (string, error) {
+	if e, ok := c.asker.(Editor); ok && c.timeout <= 0 {
+		if err := c.ctx.Err(); err != nil {
+			return "", err
+		}
+		return e.Edit(text)
+	}
+
+	return "", ErrNoEditor
+}
+
+// AskPath delegates to the underlying PathAsker if there is no prompt timeout.
+// Otherwise, the path is asked for without completion, so the prompt can time out.
+func (c *contextAsker) AskPath(prompt string) (string, error) {
+	if p, ok := c.asker.(PathAsker); ok && c.timeout <= 0 {
+		if err := c.ctx.Err(); err != nil {
+			return "", err
+		}
+		return p.AskPath(prompt)
+	}
+
+	return c.Ask(prompt)
+}
+
+// AskField asks for a field using the underlying FieldAsker until the context is cancelled or the prompt times out.
+func (c *contextAsker) AskField(f Field) (string, error) {
+	fa, ok := c.asker.(FieldAsker)
+	if !ok {
+		return "", errors.New("asker does not implement FieldAsker")
+	}
+
+	return c.run(nil, func() (string, error) {
+		return fa.AskField(f)
+	})
+}
+
+// handleTimeout handles a prompt timeout for a field.
+// If falling back is enabled, the field keeps its current or default value unless it is a required field with no value.
+func handleTimeout(f fieldInfo, asker Asker, opts *Options, err error) error {
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		return err
+	}
+
+	te.Field = f.Name
+
+	if !opts.TimeoutFallback {
+		return te
+	}
+
+	if err := applyDefault(f); err != nil {
+		return err
+	}
+
+	if f.Required && f.Value.IsZero() {
+		return te
+	}
+
+	asker.Output(opts.render(MessageTimeoutFallback, te))
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package askit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingAsker blocks on every prompt until it is released.
+type blockingAsker struct {
+	MockAsker
+	release chan struct{}
+}
+
+func (b *blockingAsker) Ask(prompt string) (string, error) {
+	<-b.release
+	return "", errors.New("released")
+}
+
+func (b *blockingAsker) AskSecret(prompt string) (string, error) {
+	<-b.release
+	return "", errors.New("released")
+}
+
+// chanAsker answers every prompt from a channel and detects concurrent calls.
+type chanAsker struct {
+	MockAsker
+	answers    chan string
+	active     int32
+	concurrent bool
+}
+
+func (c *chanAsker) Ask(prompt string) (string, error) {
+	if atomic.AddInt32(&c.active, 1) > 1 {
+		c.concurrent = true
+	}
+	defer atomic.AddInt32(&c.active, -1)
+
+	return <-c.answers, nil
+}
+
+// mockContextAsker waits for the context on every prompt.
+type mockContextAsker struct {
+	MockAsker
+	Prompts []string
+}
+
+func (m *mockContextAsker) AskContext(ctx context.Context, prompt string) (string, error) {
+	m.Prompts = append(m.Prompts, prompt)
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func (m *mockContextAsker) AskSecretContext(ctx context.Context, prompt string) (string, error) {
+	return m.AskContext(ctx, prompt)
+}
+
+func TestNewContextAsker(t *testing.T) {
+	asker := &MockAsker{}
+
+	assert.Same(t, asker, newContextAsker(context.Background(), asker, 0))
+	assert.IsType(t, &contextAsker{}, newContextAsker(context.Background(), asker, time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.IsType(t, &contextAsker{}, newContextAsker(ctx, asker, 0))
+}
+
+func TestContextAsker(t *testing.T) {
+	t.Run("Answered", func(t *testing.T) {
+		asker := &MockAsker{
+			AskMocks:       []AskMock{{OutString: "value"}},
+			AskSecretMocks: []AskSecretMock{{OutError: errors.New("io error")}},
+		}
+		c := newContextAsker(context.Background(), asker, time.Second)
+
+		ans, err := c.Ask("Value:")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", ans)
+
+		_, err = c.AskSecret("Secret:")
+		assert.EqualError(t, err, "io error")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		asker := &blockingAsker{release: make(chan struct{})}
+		defer close(asker.release)
+
+		c := newContextAsker(context.Background(), asker, 10*time.Millisecond)
+
+		_, err := c.Ask("Value:")
+		assert.Equal(t, &TimeoutError{Timeout: 10 * time.Millisecond}, err)
+
+		_, err = c.AskSecret("Secret:")
+		assert.EqualError(t, err, "timed out after 10ms")
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		asker := &blockingAsker{release: make(chan struct{})}
+		defer close(asker.release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := newContextAsker(ctx, asker, time.Minute)
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := c.Ask("Value:")
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("ParentDeadline", func(t *testing.T) {
+		asker := &mockContextAsker{}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		c := newContextAsker(ctx, asker, time.Minute)
+
+		_, err := c.AskSecret("Secret:")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, []string{"Secret:"}, asker.Prompts)
+	})
+
+	t.Run("AbandonedCall", func(t *testing.T) {
+		asker := &chanAsker{answers: make(chan string)}
+		c := newContextAsker(context.Background(), asker, 20*time.Millisecond)
+
+		_, err := c.Ask("A:")
+		assert.EqualError(t, err, "timed out after 20ms")
+
+		// The late answer goes to the abandoned call and is discarded
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			asker.answers <- "a"
+			asker.answers <- "b"
+		}()
+
+		ans, err := c.Ask("B:")
+		assert.NoError(t, err)
+		assert.Equal(t, "b", ans)
+		assert.False(t, asker.concurrent)
+	})
+
+	t.Run("ContextAsker", func(t *testing.T) {
+		asker := &mockContextAsker{}
+		c := newContextAsker(context.Background(), asker, 10*time.Millisecond)
+
+		_, err := c.Ask("Value:")
+		assert.EqualError(t, err, "timed out after 10ms")
+		assert.Equal(t, []string{"Value:"}, asker.Prompts)
+	})
+}
+
+func TestContextAsker_Select(t *testing.T) {
+	options := []string{"dev", "prod"}
+
+	t.Run("Delegated", func(t *testing.T) {
+		asker := &mockSelector{SelectOutIndex: 1, MultiSelectOutIndices: []int{0}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := newContextAsker(ctx, asker, 0).(*contextAsker)
+
+		i, err := c.Select("Select:", options, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, i)
+
+		indices, err := c.MultiSelect("Select:", options, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, indices)
+
+		cancel()
+
+		_, err = c.Select("Select:", options, -1)
+		assert.Equal(t, context.Canceled, err)
+
+		_, err = c.MultiSelect("Select:", options, nil)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("NumberedMenu", func(t *testing.T) {
+		asker := &mockSelector{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutString: "2"}, {OutString: "1,2"}},
+			},
+		}
+
+		c := newContextAsker(context.Background(), asker, time.Second).(*contextAsker)
+
+		i, err := c.Select("Select:", options, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, i)
+
+		indices, err := c.MultiSelect("Select:", options, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, indices)
+	})
+}
+
+func TestContextAsker_Edit(t *testing.T) {
+	asker := &mockEditor{EditOutText: "edited"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := newContextAsker(ctx, asker, 0).(*contextAsker)
+
+	text, err := c.Edit("text")
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", text)
+
+	cancel()
+
+	_, err = c.Edit("text")
+	assert.Equal(t, context.Canceled, err)
+
+	c = newContextAsker(context.Background(), asker, time.Second).(*contextAsker)
+
+	_, err = c.Edit("text")
+	assert.Equal(t, ErrNoEditor, err)
+}
+
+func TestAskContext(t *testing.T) {
+	type config struct {
+		Name  string `ask:"any, your name"`
+		Email string `ask:"email, your email address, required"`
+		Level string `ask:"any, the log level" default:"info"`
+	}
+
+	tests := []struct {
+		name           string
+		c              config
+		opts           []Option
+		expectedError  string
+		expectedConfig config
+	}{
+		{
+			name:           "Timeout",
+			c:              config{Name: "Jane Doe", Email: "jane.doe@example.com"},
+			opts:           []Option{WithPromptTimeout(10*time.Millisecond, false)},
+			expectedError:  "timed out waiting for Name after 10ms",
+			expectedConfig: config{Name: "Jane Doe", Email: "jane.doe@example.com"},
+		},
+		{
+			name:           "FallbackRequired",
+			c:              config{Name: "Jane Doe"},
+			opts:           []Option{WithPromptTimeout(10*time.Millisecond, true)},
+			expectedError:  "timed out waiting for Email after 10ms",
+			expectedConfig: config{Name: "Jane Doe"},
+		},
+		{
+			name:           "Fallback",
+			c:              config{Name: "Jane Doe", Email: "jane.doe@example.com"},
+			opts:           []Option{WithPromptTimeout(10*time.Millisecond, true)},
+			expectedConfig: config{Name: "Jane Doe", Email: "jane.doe@example.com", Level: "info"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			asker := &blockingAsker{release: make(chan struct{})}
+			defer close(asker.release)
+
+			c := tc.c
+			err := AskContext(context.Background(), &c, asker, tc.opts...)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				var te *TimeoutError
+				assert.ErrorAs(t, err, &te)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedConfig, c)
+		})
+	}
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		c := config{Name: "Jane Doe"}
+		err := AskContext(ctx, &c, nil, WithNonInteractive(), WithAnswers(Answers{"Name": "John Doe"}))
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, config{Name: "Jane Doe"}, c)
+	})
+}
+
+func TestHandleTimeout(t *testing.T) {
+	t.Run("OtherError", func(t *testing.T) {
+		err := handleTimeout(fieldInfo{Name: "Name"}, &MockAsker{}, &Options{}, errors.New("io error"))
+		assert.EqualError(t, err, "io error")
+	})
+
+	t.Run("InvalidDefault", func(t *testing.T) {
+		port := 0
+		f := fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "Port", Kind: KindPort, Sep: ",", Default: "http"}
+
+		err := handleTimeout(f, &MockAsker{}, &Options{TimeoutFallback: true}, &TimeoutError{Timeout: time.Second})
+		assert.EqualError(t, err, "invalid default value for Port: invalid port number entered for Port: port must be a number between 1 and 65535")
+	})
+}
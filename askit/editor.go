@@ -0,0 +1,76 @@
+package askit
+
+import (
+	"errors"
+	"strings"
+)
+
+// multilineTerminator is the line that ends a multiline input.
+const multilineTerminator = "."
+
+// ErrNoEditor is returned by an Editor when no editor is available.
+var ErrNoEditor = errors.New("no editor available")
+
+// Editor is an optional interface for an Asker to edit long texts in an external editor.
+type Editor interface {
+	// Edit opens an editor pre-filled with a text and returns the edited text.
+	// If no editor is available, ErrNoEditor is returned.
+	Edit(text string) (string, error)
+}
+
+func askForMultiline(f fieldInfo, asker Asker, opts *Options) (string, error) {
+	description := f.Description
+	if description == "" {
+		description = "text"
+	}
+
+	current := ""
+	if !f.Value.IsZero() {
+		current = formatValue(f)
+	}
+
+	if e, ok := asker.(Editor); ok && f.Kind == KindEditor {
+		header := opts.render(MessageEditorHeader, f.Name, description)
+
+		text, err := e.Edit(header + "\n" + current)
+		if err == nil {
+			return stripHeader(text, header), nil
+		}
+
+		if !errors.Is(err, ErrNoEditor) {
+			return "", err
+		}
+	}
+
+	asker.Output(opts.render(MessageMultilineHelp, description, multilineTerminator))
+
+	lines := []string{}
+	for {
+		line, err := asker.Ask(opts.render(MessageMultilineLine))
+		if err != nil {
+			return "", err
+		}
+
+		if line == multilineTerminator {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// stripHeader removes the lines of a header at the top of an edited text and the trailing new lines.
+// Only the lines left unchanged from the header are removed, so a text starting with '#' is kept.
+func stripHeader(text, header string) string {
+	lines := strings.Split(text, "\n")
+	headerLines := strings.Split(header, "\n")
+
+	i := 0
+	for i < len(lines) && i < len(headerLines) && strings.TrimRight(lines[i], "\r") == headerLines[i] {
+		i++
+	}
+
+	return strings.TrimRight(strings.Join(lines[i:], "\n"), "\r\n")
+}
@@ -0,0 +1,159 @@
+package askit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEditor struct {
+	MockAsker
+
+	EditInText  string
+	EditOutText string
+	EditOutErr  error
+}
+
+func (m *mockEditor) Edit(text string) (string, error) {
+	m.EditInText = text
+	return m.EditOutText, m.EditOutErr
+}
+
+func TestAskForMultiline(t *testing.T) {
+	tests := []struct {
+		name             string
+		kind             Kind
+		current          string
+		asker            Asker
+		expectedError    string
+		expectedVal      string
+		expectedEditText string
+	}{
+		{
+			name:          "AskFails",
+			kind:          KindMultiline,
+			asker:         &MockAsker{AskMocks: []AskMock{{OutString: "SELECT *"}, {OutError: errors.New("io error")}}},
+			expectedError: "io error",
+		},
+		{
+			name: "Multiline",
+			kind: KindMultiline,
+			asker: &MockAsker{AskMocks: []AskMock{
+				{OutString: "SELECT *"},
+				{OutString: ""},
+				{OutString: "FROM users;"},
+				{OutString: "."},
+			}},
+			expectedVal: "SELECT *\n\nFROM users;",
+		},
+		{
+			name:          "EditFails",
+			kind:          KindEditor,
+			asker:         &mockEditor{EditOutErr: errors.New("editor failed: exit status 1")},
+			expectedError: "editor failed: exit status 1",
+			expectedEditText: "# Enter a new value for Query (the query).\n" +
+				"# These two lines are ignored.\n",
+		},
+		{
+			name:    "Editor",
+			kind:    KindEditor,
+			current: "SELECT 1;",
+			asker: &mockEditor{
+				EditOutText: "# Enter a new value for Query (the query).\n" +
+					"# These two lines are ignored.\n" +
+					"SELECT *\n# not a header\nFROM users;\n\n",
+			},
+			expectedVal: "SELECT *\n# not a header\nFROM users;",
+			expectedEditText: "# Enter a new value for Query (the query).\n" +
+				"# These two lines are ignored.\n" +
+				"SELECT 1;",
+		},
+		{
+			name:    "Editor_CommentValue",
+			kind:    KindEditor,
+			current: "# Title\n\nSome text.",
+			asker: &mockEditor{
+				EditOutText: "# Enter a new value for Query (the query).\n" +
+					"# These two lines are ignored.\n" +
+					"# Title\n\nSome text.\n",
+			},
+			expectedVal: "# Title\n\nSome text.",
+			expectedEditText: "# Enter a new value for Query (the query).\n" +
+				"# These two lines are ignored.\n" +
+				"# Title\n\nSome text.",
+		},
+		{
+			name: "NoEditor",
+			kind: KindEditor,
+			asker: &mockEditor{
+				MockAsker: MockAsker{AskMocks: []AskMock{
+					{OutString: "SELECT 1;"},
+					{OutString: "."},
+				}},
+				EditOutErr: ErrNoEditor,
+			},
+			expectedVal: "SELECT 1;",
+			expectedEditText: "# Enter a new value for Query (the query).\n" +
+				"# These two lines are ignored.\n",
+		},
+		{
+			name: "NotEditor",
+			kind: KindEditor,
+			asker: &MockAsker{AskMocks: []AskMock{
+				{OutString: "SELECT 1;"},
+				{OutString: "."},
+			}},
+			expectedVal: "SELECT 1;",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query := tc.current
+			f := fieldInfo{
+				Value:       reflect.ValueOf(&query).Elem(),
+				Name:        "Query",
+				Kind:        tc.kind,
+				Description: "the query",
+				Sep:         ",",
+			}
+
+			val, err := askForMultiline(f, tc.asker, &Options{})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedVal, val)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			if e, ok := tc.asker.(*mockEditor); ok {
+				assert.Equal(t, tc.expectedEditText, e.EditInText)
+			}
+		})
+	}
+}
+
+func TestStripHeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expectedText string
+	}{
+		{"Empty", "", ""},
+		{"OnlyHeader", "# header 1\n# header 2\n", ""},
+		{"NoHeader", "line 1\nline 2\n", "line 1\nline 2"},
+		{"Header", "# header 1\r\n# header 2\nline 1\n# line 2\r\n\n", "line 1\n# line 2"},
+		{"CommentValue", "# header 1\n# header 2\n# line 1\nline 2", "# line 1\nline 2"},
+		{"HeaderEdited", "# header 1\n# edited\nline 1", "# edited\nline 1"},
+		{"HeaderRemoved", "# line 1\nline 2", "# line 1\nline 2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedText, stripHeader(tc.text, "# header 1\n# header 2"))
+		})
+	}
+}
@@ -5,15 +5,10 @@ import (
 	"os"
 
 	"github.com/gardenbed/charm/askit"
-	"github.com/mitchellh/cli"
 )
 
 func main() {
-	asker := &cli.BasicUi{
-		Reader:      os.Stdin,
-		Writer:      os.Stdout,
-		ErrorWriter: os.Stderr,
-	}
+	asker := askit.NewTerminal(os.Stdin, os.Stdout)
 
 	info := struct {
 		ID      int    `ask:"any, your identification number"`
@@ -4,15 +4,10 @@ import (
 	"os"
 
 	"github.com/gardenbed/charm/askit"
-	"github.com/mitchellh/cli"
 )
 
 func ExampleAsk() {
-	asker := &cli.BasicUi{
-		Reader:      os.Stdin,
-		Writer:      os.Stdout,
-		ErrorWriter: os.Stderr,
-	}
+	asker := askit.NewTerminal(os.Stdin, os.Stdout)
 
 	info := struct {
 		Name  string `ask:"any, your full name"`
@@ -0,0 +1,80 @@
+package askit
+
+import "reflect"
+
+// Field describes a field asked for by a FieldAsker.
+type Field struct {
+	// Name is the path of the field (i.e. Server.Port).
+	Name string `json:"name"`
+	// Kind is the kind of input.
+	Kind Kind `json:"kind"`
+	// Description is the description of the field from the ask tag.
+	Description string `json:"description,omitempty"`
+	// Value is the current value of the field, masked for secrets.
+	Value string `json:"value,omitempty"`
+	// Default is the default value of the field, masked for secrets.
+	Default string `json:"default,omitempty"`
+	// Options are the choices for select and multiselect fields.
+	Options []string `json:"options,omitempty"`
+	// Multiple determines whether the field accepts multiple values separated by Sep.
+	Multiple bool   `json:"multiple,omitempty"`
+	Sep      string `json:"sep,omitempty"`
+	// Required determines whether a value must be entered for the field.
+	Required bool `json:"required,omitempty"`
+	// Secret determines whether the value of the field is a secret.
+	Secret bool `json:"secret,omitempty"`
+	// Error is the error for the previous answer if it was invalid.
+	Error string `json:"error,omitempty"`
+}
+
+// FieldAsker is an optional interface for an Asker to ask for the value of a field as a whole.
+// A FieldAsker is not asked whether or not to enter a value, and an empty answer keeps the current or default value.
+// For a field with multiple values, the values are separated by the separator of the field.
+// To clear a field, ClearAnswer is returned (for a multiselect field, no options are selected).
+// If an answer is invalid, the field is asked for again with the error.
+type FieldAsker interface {
+	AskField(Field) (string, error)
+}
+
+// asFieldAsker returns the FieldAsker for an Asker if it is one.
+func asFieldAsker(asker Asker) (FieldAsker, bool) {
+	if c, ok := asker.(*contextAsker); ok {
+		if _, ok := c.asker.(FieldAsker); !ok {
+			return nil, false
+		}
+	}
+
+	fa, ok := asker.(FieldAsker)
+	return fa, ok
+}
+
+// newField creates the description of a field for a FieldAsker.
+func newField(f fieldInfo) Field {
+	secret := isMasked(f.Kind)
+
+	field := Field{
+		Name:        f.Name,
+		Kind:        f.Kind,
+		Description: f.Description,
+		Default:     f.Default,
+		Options:     f.Options,
+		Multiple:    f.Value.Kind() == reflect.Slice,
+		Sep:         f.Sep,
+		Required:    f.Required,
+		Secret:      secret,
+	}
+
+	if !f.Value.IsZero() {
+		field.Value = formatValue(f)
+	}
+
+	if secret && field.Default != "" {
+		field.Default = "*******"
+	}
+
+	if f.LastError != nil {
+		field.Error = f.LastError.Error()
+	}
+
+	return field
+}
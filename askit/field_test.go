@@ -0,0 +1,154 @@
+package askit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFieldAsker answers the fields from a list of answers.
+type mockFieldAsker struct {
+	MockAsker
+	Fields  []Field
+	Answers []string
+	Block   chan struct{}
+}
+
+func (m *mockFieldAsker) AskField(f Field) (string, error) {
+	if m.Block != nil {
+		<-m.Block
+		return "", errors.New("released")
+	}
+
+	i := len(m.Fields)
+	m.Fields = append(m.Fields, f)
+	return m.Answers[i], nil
+}
+
+func TestAsFieldAsker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, ok := asFieldAsker(&MockAsker{})
+	assert.False(t, ok)
+
+	_, ok = asFieldAsker(newContextAsker(ctx, &MockAsker{}, 0))
+	assert.False(t, ok)
+
+	_, ok = asFieldAsker(&mockFieldAsker{})
+	assert.True(t, ok)
+
+	_, ok = asFieldAsker(newContextAsker(ctx, &mockFieldAsker{}, 0))
+	assert.True(t, ok)
+}
+
+func TestNewField(t *testing.T) {
+	token := "token"
+	f := fieldInfo{
+		Value:       reflect.ValueOf(&token).Elem(),
+		Name:        "Token",
+		Kind:        KindSecret,
+		Description: "your token",
+		Sep:         ",",
+		Default:     "default_token",
+		Required:    true,
+		LastError:   errors.New("invalid token"),
+	}
+
+	assert.Equal(t, Field{
+		Name:        "Token",
+		Kind:        KindSecret,
+		Description: "your token",
+		Value:       "*******",
+		Default:     "*******",
+		Sep:         ",",
+		Required:    true,
+		Secret:      true,
+		Error:       "invalid token",
+	}, newField(f))
+}
+
+func TestContextAsker_AskField(t *testing.T) {
+	t.Run("NotFieldAsker", func(t *testing.T) {
+		c := newContextAsker(context.Background(), &MockAsker{}, time.Second).(*contextAsker)
+
+		_, err := c.AskField(Field{Name: "Name"})
+		assert.EqualError(t, err, "asker does not implement FieldAsker")
+	})
+
+	t.Run("Answered", func(t *testing.T) {
+		asker := &mockFieldAsker{Answers: []string{"Jane"}}
+		c := newContextAsker(context.Background(), asker, time.Second).(*contextAsker)
+
+		ans, err := c.AskField(Field{Name: "Name"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane", ans)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		asker := &mockFieldAsker{Block: make(chan struct{})}
+		defer close(asker.Block)
+
+		c := newContextAsker(context.Background(), asker, 10*time.Millisecond).(*contextAsker)
+
+		_, err := c.AskField(Field{Name: "Name"})
+		assert.EqualError(t, err, "timed out after 10ms")
+	})
+}
+
+func TestAskForField_FieldAsker(t *testing.T) {
+	port := 8080
+	f := fieldInfo{Value: reflect.ValueOf(&port).Elem(), Name: "Port", Kind: KindPort, Sep: ","}
+
+	asker := &mockFieldAsker{Answers: []string{"http", "0", "443"}}
+
+	err := askForField(f, asker, &Options{MaxAttempts: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 443, port)
+	assert.Equal(t, 0, asker.AskIndex)
+	assert.Len(t, asker.Fields, 3)
+	assert.Empty(t, asker.Fields[0].Error)
+	assert.Equal(t, "invalid port number entered for Port: port must be a number between 1 and 65535", asker.Fields[1].Error)
+	assert.Equal(t, "8080", asker.Fields[2].Value)
+}
+
+func TestAskForValue_FieldAsker_MultiSelect(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        []string
+		def            string
+		answer         string
+		expectedGroups []string
+	}{
+		{"KeepCurrent", []string{"dev"}, "", "", []string{"dev"}},
+		{"KeepDefault", nil, "admin|ops", "", []string{"admin", "ops"}},
+		{"KeepNone", nil, "", "", []string{}},
+		{"Selected", []string{"dev"}, "", "admin|ops", []string{"admin", "ops"}},
+		{"NoneSelected", []string{"dev"}, "admin", ClearAnswer, []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			groups := tc.current
+			f := fieldInfo{
+				Value:   reflect.ValueOf(&groups).Elem(),
+				Name:    "Groups",
+				Kind:    KindMultiSelect,
+				Options: []string{"admin", "dev", "ops"},
+				Sep:     "|",
+				Default: tc.def,
+			}
+
+			asker := &mockFieldAsker{Answers: []string{tc.answer}}
+
+			v, err := askForValue(f, asker, &Options{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedGroups, v.Interface())
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package askit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// JSONMessage types.
+const (
+	JSONMessageOutput = "output"
+	JSONMessageAsk    = "ask"
+	JSONMessageField  = "field"
+)
+
+var ansiRE = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// JSONMessage is a message written by a JSONAsker.
+// Every message is written as one JSON-encoded object per line.
+type JSONMessage struct {
+	Type string `json:"type"`
+	// ID identifies a prompt, so its answer can be matched to it (ask and field messages).
+	ID int `json:"id,omitempty"`
+	// Message is the text of an output message.
+	Message string `json:"message,omitempty"`
+	// Prompt is the text of an ask message.
+	Prompt string `json:"prompt,omitempty"`
+	// Secret determines whether the answer to an ask message is a secret.
+	Secret bool `json:"secret,omitempty"`
+	// Field describes the field asked for by a field message.
+	Field *Field `json:"field,omitempty"`
+}
+
+// JSONAnswer is an answer read by a JSONAsker.
+// Every answer is read as one JSON-encoded object per line.
+type JSONAnswer struct {
+	// ID is the identifier of the prompt answered.
+	// If it is zero, the answer is for the last prompt.
+	ID int `json:"id,omitempty"`
+	// Answer is the value entered.
+	Answer string `json:"answer"`
+	// Values are the values entered for a field with multiple values.
+	// If set, they are joined by the separator of the field.
+	// If set to an empty list, the field is cleared (for a multiselect field, no options are selected).
+	Values []string `json:"values,omitempty"`
+	// Error is set if the prompt could not be answered (i.e. cancelled by the user).
+	Error string `json:"error,omitempty"`
+}
+
+// JSONAsker is an Asker that speaks a line-delimited JSON protocol on a reader and a writer.
+// It allows any front-end (i.e. an editor extension or a GUI) to answer the prompts without a terminal.
+//
+// Every field is asked for with a field message describing the field, including its current value (masked for secrets) and options.
+// Other prompts (i.e. confirmations) are asked for with ask messages, and all other messages are written as output messages.
+// ANSI escape codes are removed from all texts.
+type JSONAsker struct {
+	// mu guards writing messages, and readMu guards reading answers.
+	// They are separate, so messages can be written while a read abandoned due to cancellation is pending.
+	mu     sync.Mutex
+	readMu sync.Mutex
+	in     *bufio.Scanner
+	enc    *json.Encoder
+	id     int
+}
+
+// NewJSONAsker creates a new JSONAsker for reading answers from in and writing messages to out.
+// Usually, in and out are os.Stdin and os.Stdout respectively.
+func NewJSONAsker(in io.Reader, out io.Writer) *JSONAsker {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+
+	return &JSONAsker{
+		in:  bufio.NewScanner(in),
+		enc: enc,
+	}
+}
+
+// Output writes an output message.
+func (j *JSONAsker) Output(message string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_ = j.enc.Encode(JSONMessage{
+		Type:    JSONMessageOutput,
+		Message: ansiRE.ReplaceAllString(message, ""),
+	})
+}
+
+// Ask writes an ask message and reads the answer.
+func (j *JSONAsker) Ask(prompt string) (string, error) {
+	a, err := j.ask(JSONMessage{
+		Type:   JSONMessageAsk,
+		Prompt: ansiRE.ReplaceAllString(prompt, ""),
+	})
+
+	return a.Answer, err
+}
+
+// AskSecret writes an ask message for a secret and reads the answer.
+func (j *JSONAsker) AskSecret(prompt string) (string, error) {
+	a, err := j.ask(JSONMessage{
+		Type:   JSONMessageAsk,
+		Prompt: ansiRE.ReplaceAllString(prompt, ""),
+		Secret: true,
+	})
+
+	return a.Answer, err
+}
+
+// AskField writes a field message and reads the answer.
+func (j *JSONAsker) AskField(f Field) (string, error) {
+	a, err := j.ask(JSONMessage{
+		Type:  JSONMessageField,
+		Field: &f,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if a.Values != nil {
+		// An empty list of values clears the field
+		if len(a.Values) == 0 {
+			return ClearAnswer, nil
+		}
+		return strings.Join(a.Values, f.Sep), nil
+	}
+
+	return a.Answer, nil
+}
+
+func (j *JSONAsker) ask(m JSONMessage) (JSONAnswer, error) {
+	j.readMu.Lock()
+	defer j.readMu.Unlock()
+
+	if err := j.write(&m); err != nil {
+		return JSONAnswer{}, err
+	}
+
+	if !j.in.Scan() {
+		if err := j.in.Err(); err != nil {
+			return JSONAnswer{}, err
+		}
+		return JSONAnswer{}, io.EOF
+	}
+
+	var a JSONAnswer
+	if err := json.Unmarshal(j.in.Bytes(), &a); err != nil {
+		return JSONAnswer{}, fmt.Errorf("invalid answer: %s", err)
+	}
+
+	if a.ID != 0 && a.ID != m.ID {
+		return JSONAnswer{}, fmt.Errorf("answer for prompt %d received for prompt %d", a.ID, m.ID)
+	}
+
+	if a.Error != "" {
+		return JSONAnswer{}, errors.New(a.Error)
+	}
+
+	return a, nil
+}
+
+func (j *JSONAsker) write(m *JSONMessage) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.id++
+	m.ID = j.id
+
+	return j.enc.Encode(m)
+}
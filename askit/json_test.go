@@ -0,0 +1,207 @@
+package askit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeMessages decodes the messages written by a JSONAsker.
+func decodeMessages(t *testing.T, out *bytes.Buffer) []JSONMessage {
+	msgs := []JSONMessage{}
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		var m JSONMessage
+		assert.NoError(t, dec.Decode(&m))
+		msgs = append(msgs, m)
+	}
+
+	return msgs
+}
+
+func TestJSONAsker_Output(t *testing.T) {
+	out := new(bytes.Buffer)
+	j := NewJSONAsker(strings.NewReader(""), out)
+
+	j.Output("\033[1mName\033[0m <jane>")
+
+	assert.Equal(t, "{\"type\":\"output\",\"message\":\"Name <jane>\"}\n", out.String())
+}
+
+func TestJSONAsker_Ask(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		secret           bool
+		expectedAnswer   string
+		expectedError    string
+		expectedMessages []JSONMessage
+	}{
+		{
+			name:          "EOF",
+			in:            "",
+			expectedError: "EOF",
+		},
+		{
+			name:          "InvalidAnswer",
+			in:            "yes\n",
+			expectedError: "invalid answer: invalid character 'y' looking for beginning of value",
+		},
+		{
+			name:          "IDMismatch",
+			in:            `{"id":2,"answer":"yes"}` + "\n",
+			expectedError: "answer for prompt 2 received for prompt 1",
+		},
+		{
+			name:          "Cancelled",
+			in:            `{"id":1,"error":"cancelled by user"}` + "\n",
+			expectedError: "cancelled by user",
+		},
+		{
+			name:           "Answered",
+			in:             `{"id":1,"answer":"yes"}` + "\n",
+			expectedAnswer: "yes",
+			expectedMessages: []JSONMessage{
+				{Type: JSONMessageAsk, ID: 1, Prompt: "Save the changes?"},
+			},
+		},
+		{
+			name:           "Secret",
+			in:             `{"answer":"token"}` + "\n",
+			secret:         true,
+			expectedAnswer: "token",
+			expectedMessages: []JSONMessage{
+				{Type: JSONMessageAsk, ID: 1, Prompt: "Save the changes?", Secret: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			j := NewJSONAsker(strings.NewReader(tc.in), out)
+
+			ask := j.Ask
+			if tc.secret {
+				ask = j.AskSecret
+			}
+
+			ans, err := ask("\033[1mSave the changes?\033[0m")
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAnswer, ans)
+				assert.Equal(t, tc.expectedMessages, decodeMessages(t, out))
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestJSONAsker_AskField(t *testing.T) {
+	in := strings.Join([]string{
+		`{"id":1,"values":["web","db"]}`,
+		`{"id":2,"answer":"prod"}`,
+		`{"id":3,"values":[]}`,
+		`{"id":4,"error":"cancelled"}`,
+	}, "\n")
+
+	out := new(bytes.Buffer)
+	j := NewJSONAsker(strings.NewReader(in), out)
+
+	ans, err := j.AskField(Field{Name: "Tags", Kind: KindAny, Multiple: true, Sep: "|"})
+	assert.NoError(t, err)
+	assert.Equal(t, "web|db", ans)
+
+	ans, err = j.AskField(Field{Name: "Env", Kind: KindSelect, Options: []string{"dev", "prod"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", ans)
+
+	ans, err = j.AskField(Field{Name: "Groups", Kind: KindMultiSelect, Options: []string{"admin", "dev"}, Multiple: true, Sep: ","})
+	assert.NoError(t, err)
+	assert.Equal(t, ClearAnswer, ans)
+
+	_, err = j.AskField(Field{Name: "Name", Kind: KindAny})
+	assert.EqualError(t, err, "cancelled")
+
+	msgs := decodeMessages(t, out)
+	assert.Len(t, msgs, 4)
+	assert.Equal(t, JSONMessage{Type: JSONMessageField, ID: 2, Field: &Field{Name: "Env", Kind: KindSelect, Options: []string{"dev", "prod"}}}, msgs[1])
+}
+
+func TestAsk_JSONAsker(t *testing.T) {
+	type config struct {
+		Name  string   `ask:"any, your name"`
+		Email string   `ask:"email, your email address, required"`
+		Token string   `ask:"secret, your access token"`
+		Env   string   `ask:"select, the environment" options:"dev|prod" default:"dev"`
+		Tags  []string `ask:"any, your tags"`
+	}
+
+	in := strings.Join([]string{
+		`{"id":1,"answer":""}`,
+		`{"id":2,"answer":"jane"}`,
+		`{"id":3,"answer":"jane@example.com"}`,
+		`{"id":4,"answer":":clear"}`,
+		`{"id":5,"answer":"y"}`,
+		`{"id":6,"answer":"prod"}`,
+		`{"id":7,"values":["web","api"]}`,
+	}, "\n")
+
+	out := new(bytes.Buffer)
+	j := NewJSONAsker(strings.NewReader(in), out)
+
+	c := config{Name: "Jane Doe", Token: "token"}
+	err := Ask(&c, j)
+
+	assert.NoError(t, err)
+	assert.Equal(t, config{Name: "Jane Doe", Email: "jane@example.com", Env: "prod", Tags: []string{"web", "api"}}, c)
+
+	assert.Equal(t, []JSONMessage{
+		{Type: JSONMessageField, ID: 1, Field: &Field{Name: "Name", Kind: KindAny, Description: "your name", Value: "Jane Doe", Sep: ","}},
+		{Type: JSONMessageField, ID: 2, Field: &Field{Name: "Email", Kind: KindEmail, Description: "your email address", Sep: ",", Required: true}},
+		{Type: JSONMessageField, ID: 3, Field: &Field{Name: "Email", Kind: KindEmail, Description: "your email address", Sep: ",", Required: true, Error: "invalid email address entered for Email: mail: missing '@' or angle-addr"}},
+		{Type: JSONMessageField, ID: 4, Field: &Field{Name: "Token", Kind: KindSecret, Description: "your access token", Value: "*******", Sep: ",", Secret: true}},
+		{Type: JSONMessageAsk, ID: 5, Prompt: "  • Are you sure you want to clear Token [y/N]?"},
+		{Type: JSONMessageField, ID: 6, Field: &Field{Name: "Env", Kind: KindSelect, Description: "the environment", Default: "dev", Options: []string{"dev", "prod"}, Sep: ","}},
+		{Type: JSONMessageField, ID: 7, Field: &Field{Name: "Tags", Kind: KindAny, Description: "your tags", Multiple: true, Sep: ","}},
+	}, decodeMessages(t, out))
+}
+
+func TestAsk_JSONAsker_Timeout(t *testing.T) {
+	type spec struct {
+		Name string `ask:"any, your name"`
+	}
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	out := new(bytes.Buffer)
+	j := NewJSONAsker(pr, out)
+
+	s := &spec{Name: "Jane Doe"}
+	done := make(chan error, 1)
+	go func() {
+		done <- Ask(s, j, WithPromptTimeout(50*time.Millisecond, true))
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for Ask to return")
+	}
+
+	assert.Equal(t, &spec{Name: "Jane Doe"}, s)
+
+	msgs := decodeMessages(t, out)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, JSONMessageField, msgs[0].Type)
+	assert.Equal(t, JSONMessage{Type: JSONMessageOutput, Message: "  • timed out waiting for Name after 50ms (keeping the current value)"}, msgs[1])
+}
@@ -0,0 +1,201 @@
+package askit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	hostnameLabelRE = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+	semverRE        = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-((0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(\.(0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(\+([0-9a-zA-Z-]+(\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// KindSpec determines how the inputs of a kind are normalized, validated, and presented.
+type KindSpec struct {
+	// Normalize is an optional function for normalizing an input before validation (trimming, lowercasing, etc.).
+	Normalize func(string) string
+	// Validate is an optional function for validating a normalized input.
+	Validate func(string) error
+	// Mask determines whether inputs are secret.
+	// Secret inputs are read without echo and masked in outputs.
+	Mask bool
+	// Hint is an optional short description of the expected input (e.g. "URL").
+	// It is used in prompts for fields without a description and in error messages.
+	Hint string
+}
+
+var kinds = struct {
+	sync.RWMutex
+	m map[Kind]KindSpec
+}{
+	m: map[Kind]KindSpec{
+		KindAny: {},
+		KindEmail: {
+			Normalize: strings.TrimSpace,
+			Validate:  validateEmail,
+			Hint:      "email address",
+		},
+		KindSecret: {
+			Mask: true,
+		},
+		KindURL: {
+			Normalize: strings.TrimSpace,
+			Validate:  validateURL,
+			Hint:      "URL",
+		},
+		KindHostname: {
+			Normalize: normalizeHostname,
+			Validate:  validateHostname,
+			Hint:      "hostname",
+		},
+		KindPort: {
+			Normalize: strings.TrimSpace,
+			Validate:  validatePort,
+			Hint:      "port number",
+		},
+		KindPath: {
+			Normalize: expandPath,
+			Hint:      "path",
+		},
+		KindPathExists: {
+			Validate: validatePathExists,
+			Hint:     "existing path",
+		},
+		KindSemver: {
+			Normalize: strings.TrimSpace,
+			Validate:  validateSemver,
+			Hint:      "semantic version",
+		},
+		KindIP: {
+			Normalize: strings.TrimSpace,
+			Validate:  validateIP,
+			Hint:      "IP address",
+		},
+		KindCIDR: {
+			Normalize: strings.TrimSpace,
+			Validate:  validateCIDR,
+			Hint:      "CIDR block",
+		},
+		KindEditor: {
+			Hint: "text",
+		},
+		KindMultiline: {
+			Hint: "text",
+		},
+	},
+}
+
+// RegisterKind registers a new kind or replaces an existing one.
+// Once registered, any field can use the kind in the ask tag (ask:"kind, description").
+// The select, multiselect, editor, and multiline kinds cannot be replaced.
+func RegisterKind(kind Kind, spec KindSpec) error {
+	if kind == "" || strings.ContainsAny(string(kind), ", ") {
+		return fmt.Errorf("invalid kind name: %q", kind)
+	}
+
+	switch kind {
+	case KindSelect, KindMultiSelect, KindEditor, KindMultiline:
+		return fmt.Errorf("cannot replace built-in kind: %s", kind)
+	}
+
+	kinds.Lock()
+	defer kinds.Unlock()
+
+	kinds.m[kind] = spec
+
+	return nil
+}
+
+// lookupKind returns the specification of a registered kind.
+func lookupKind(kind Kind) (KindSpec, bool) {
+	kinds.RLock()
+	defer kinds.RUnlock()
+
+	spec, ok := kinds.m[kind]
+	return spec, ok
+}
+
+// isMasked determines whether the inputs of a kind are secret.
+func isMasked(kind Kind) bool {
+	spec, _ := lookupKind(kind)
+	return spec.Mask
+}
+
+func validateEmail(val string) error {
+	_, err := mail.ParseAddress(val)
+	return err
+}
+
+func validateURL(val string) error {
+	u, err := url.Parse(val)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return errors.New("missing scheme or host")
+	}
+
+	return nil
+}
+
+func normalizeHostname(val string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(val)), ".")
+}
+
+func validateHostname(val string) error {
+	if val == "" || len(val) > 253 {
+		return errors.New("hostname must be between 1 and 253 characters")
+	}
+
+	for _, label := range strings.Split(val, ".") {
+		if !hostnameLabelRE.MatchString(label) {
+			return fmt.Errorf("invalid hostname label: %q", label)
+		}
+	}
+
+	return nil
+}
+
+func validatePort(val string) error {
+	port, err := strconv.ParseUint(val, 10, 16)
+	if err != nil || port == 0 {
+		return errors.New("port must be a number between 1 and 65535")
+	}
+
+	return nil
+}
+
+func validatePathExists(val string) error {
+	_, err := os.Stat(val)
+	return err
+}
+
+func validateSemver(val string) error {
+	if !semverRE.MatchString(val) {
+		return errors.New("version must be in the MAJOR.MINOR.PATCH format")
+	}
+
+	return nil
+}
+
+func validateIP(val string) error {
+	if net.ParseIP(val) == nil {
+		return errors.New("not an IPv4 or IPv6 address")
+	}
+
+	return nil
+}
+
+func validateCIDR(val string) error {
+	_, _, err := net.ParseCIDR(val)
+	return err
+}
@@ -0,0 +1,143 @@
+package askit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterKind(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          Kind
+		spec          KindSpec
+		expectedError string
+	}{
+		{
+			name:          "EmptyName",
+			kind:          "",
+			expectedError: `invalid kind name: ""`,
+		},
+		{
+			name:          "InvalidName",
+			kind:          "phone number",
+			expectedError: `invalid kind name: "phone number"`,
+		},
+		{
+			name:          "BuiltInSelect",
+			kind:          KindSelect,
+			expectedError: "cannot replace built-in kind: select",
+		},
+		{
+			name:          "BuiltInEditor",
+			kind:          KindEditor,
+			expectedError: "cannot replace built-in kind: editor",
+		},
+		{
+			name: "Success",
+			kind: "phone",
+			spec: KindSpec{
+				Normalize: func(val string) string {
+					return strings.ReplaceAll(val, " ", "")
+				},
+				Validate: func(val string) error {
+					if !strings.HasPrefix(val, "+") {
+						return errors.New("missing country code")
+					}
+					return nil
+				},
+				Hint: "phone number",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterKind(tc.kind, tc.spec)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.True(t, isKindSupported(string(tc.kind)))
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+
+	t.Run("Ask", func(t *testing.T) {
+		s := &struct {
+			Phone string `ask:"phone"`
+		}{}
+
+		asker := &MockAsker{
+			AskMocks: []AskMock{
+				{OutString: "Y"}, {OutString: "1 555 0100"}, {OutString: "+1 555 0100"},
+			},
+		}
+
+		err := Ask(s, asker)
+		assert.NoError(t, err)
+		assert.Equal(t, "+15550100", s.Phone)
+		assert.Equal(t, "  • Enter a new value (phone number):", asker.AskMocks[1].InPrompt)
+	})
+}
+
+func TestIsMasked(t *testing.T) {
+	assert.True(t, isMasked(KindSecret))
+	assert.False(t, isMasked(KindAny))
+	assert.False(t, isMasked("unknown"))
+}
+
+func TestBuiltInKinds(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		kind          Kind
+		val           string
+		expectedValid bool
+	}{
+		{KindEmail, " jane.doe@example.com ", true},
+		{KindEmail, "jane.doe", false},
+		{KindURL, "https://example.com/path", true},
+		{KindURL, "example.com", false},
+		{KindURL, "://", false},
+		{KindHostname, "Service-1.Example.com.", true},
+		{KindHostname, "localhost", true},
+		{KindHostname, "", false},
+		{KindHostname, "-service.example.com", false},
+		{KindHostname, "service_1.example.com", false},
+		{KindHostname, strings.Repeat("a", 64) + ".com", false},
+		{KindPort, "8080", true},
+		{KindPort, "0", false},
+		{KindPort, "65536", false},
+		{KindPort, "http", false},
+		{KindPathExists, dir, true},
+		{KindPathExists, dir + "/missing", false},
+		{KindSemver, "1.2.3", true},
+		{KindSemver, "v1.2.3-rc.1+build.5", true},
+		{KindSemver, "1.2", false},
+		{KindSemver, "01.2.3", false},
+		{KindIP, "192.168.0.1", true},
+		{KindIP, "::1", true},
+		{KindIP, "192.168.0.256", false},
+		{KindCIDR, "10.0.0.0/8", true},
+		{KindCIDR, "10.0.0.0", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.kind)+"/"+tc.val, func(t *testing.T) {
+			spec, ok := lookupKind(tc.kind)
+			assert.True(t, ok)
+
+			val := tc.val
+			if spec.Normalize != nil {
+				val = spec.Normalize(val)
+			}
+
+			err := spec.Validate(val)
+			assert.Equal(t, tc.expectedValid, err == nil)
+		})
+	}
+}
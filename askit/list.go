@@ -0,0 +1,147 @@
+package askit
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const listOpt = "list"
+
+// askForList asks for the elements of a slice field one at a time.
+// An element can be added by entering it, and existing elements can be removed or moved using commands.
+// An empty line finishes the list.
+func askForList(f fieldInfo, asker Asker, opts *Options) (reflect.Value, error) {
+	spec, _ := lookupKind(f.Kind)
+
+	description := f.Description
+	if description == "" {
+		description = spec.Hint
+	}
+
+	var prompt string
+	if description == "" {
+		prompt = opts.render(MessageAddItem)
+	} else {
+		prompt = opts.render(MessageAddItemHint, description)
+	}
+
+	askFunc := askFuncFor(f, asker)
+
+	// Elements are kept separately, so they can contain the separator
+	items := make([]reflect.Value, f.Value.Len())
+	for i := range items {
+		items[i] = f.Value.Index(i)
+	}
+
+	asker.Output(opts.render(MessageListHelp))
+	outputItems(f, asker, opts, items)
+
+	for {
+		ans, err := askFunc(prompt)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		switch {
+		case ans == "":
+			if f.Required && len(items) == 0 {
+				asker.Output(opts.render(MessageTryAgain, opts.render(MessageValueRequired, f.Name)))
+				continue
+			}
+
+			// A list with no elements is set to nil, the same as a cleared field
+			if len(items) == 0 {
+				return reflect.Zero(f.Value.Type()), nil
+			}
+
+			v := reflect.MakeSlice(f.Value.Type(), 0, len(items))
+			return reflect.Append(v, items...), nil
+
+		// A value starting with a colon is entered with an extra colon
+		case strings.HasPrefix(ans, ":") && !strings.HasPrefix(ans, "::"):
+			if items, err = runListCommand(items, ans[1:]); err != nil {
+				asker.Output(opts.render(MessageTryAgain, err))
+				continue
+			}
+
+		default:
+			ans = strings.TrimPrefix(ans, ":")
+
+			// Every element is validated separately
+			elem := f
+			elem.Value = reflect.New(f.Value.Type().Elem()).Elem()
+
+			v, err := parseValue(elem, ans)
+			if err != nil {
+				asker.Output(opts.render(MessageTryAgain, err))
+				continue
+			}
+
+			items = append(items, v)
+		}
+
+		outputItems(f, asker, opts, items)
+	}
+}
+
+func outputItems(f fieldInfo, asker Asker, opts *Options, items []reflect.Value) {
+	for i, item := range items {
+		if isMasked(f.Kind) {
+			asker.Output(opts.render(MessageItem, i+1, "*******"))
+		} else {
+			asker.Output(opts.render(MessageItem, i+1, stringify(item)))
+		}
+	}
+}
+
+// runListCommand runs a list-editing command and returns the updated items.
+func runListCommand(items []reflect.Value, cmd string) ([]reflect.Value, error) {
+	args := strings.Fields(cmd)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("unknown command: :%s", cmd)
+	}
+
+	switch {
+	case args[0] == "clear" && len(args) == 1:
+		return []reflect.Value{}, nil
+
+	case args[0] == "rm" && len(args) == 2:
+		i, err := parseItemNumber(args[1], len(items))
+		if err != nil {
+			return nil, err
+		}
+
+		return append(items[:i:i], items[i+1:]...), nil
+
+	case args[0] == "mv" && len(args) == 3:
+		from, err := parseItemNumber(args[1], len(items))
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := parseItemNumber(args[2], len(items))
+		if err != nil {
+			return nil, err
+		}
+
+		item := items[from]
+		moved := append(items[:from:from], items[from+1:]...)
+		moved = append(moved[:to:to], append([]reflect.Value{item}, moved[to:]...)...)
+
+		return moved, nil
+
+	default:
+		return nil, fmt.Errorf("unknown command: :%s", cmd)
+	}
+}
+
+func parseItemNumber(val string, n int) (int, error) {
+	i, err := strconv.Atoi(val)
+	if err != nil || i < 1 || i > n {
+		return -1, fmt.Errorf("invalid item: %s", val)
+	}
+
+	return i - 1, nil
+}
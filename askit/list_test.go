@@ -0,0 +1,233 @@
+package askit
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAskForList(t *testing.T) {
+	tests := []struct {
+		name            string
+		tags            []string
+		kind            Kind
+		required        bool
+		asker           *mockOutputAsker
+		expectedError   string
+		expectedValue   []string
+		expectedOutputs []string
+	}{
+		{
+			name: "AskFails",
+			tags: []string{"web"},
+			kind: KindAny,
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{{OutError: errors.New("io error")}},
+				},
+			},
+			expectedError: "io error",
+		},
+		{
+			name: "KeepItems",
+			tags: []string{"web", "api"},
+			kind: KindAny,
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{{OutString: ""}},
+				},
+			},
+			expectedValue: []string{"web", "api"},
+			expectedOutputs: []string{
+				"  • Enter one item per line and an empty line to finish (:rm N to remove, :mv N M to move, :clear to remove all)",
+				"      1) web",
+				"      2) api",
+			},
+		},
+		{
+			name: "EditItems",
+			tags: []string{"web", "api"},
+			kind: KindAny,
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "db,cache"},
+						{OutString: ":rm 1"},
+						{OutString: ":mv 2 1"},
+						{OutString: "::colon"},
+						{OutString: ""},
+					},
+				},
+			},
+			expectedValue: []string{"db,cache", "api", ":colon"},
+		},
+		{
+			name: "ClearItems",
+			tags: []string{"web", "api"},
+			kind: KindAny,
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: ":clear"},
+						{OutString: ""},
+					},
+				},
+			},
+			expectedValue: nil,
+		},
+		{
+			name: "InvalidInputs",
+			tags: []string{"example.com"},
+			kind: KindHostname,
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "-invalid-"},
+						{OutString: ":rm 2"},
+						{OutString: ":mv 1"},
+						{OutString: ":"},
+						{OutString: ":clear"},
+						{OutString: ""},
+						{OutString: "example.org"},
+						{OutString: ""},
+					},
+				},
+			},
+			required:      true,
+			expectedValue: []string{"example.org"},
+			expectedOutputs: []string{
+				"  • Enter one item per line and an empty line to finish (:rm N to remove, :mv N M to move, :clear to remove all)",
+				"      1) example.com",
+				`  • invalid hostname entered for Hosts: invalid hostname label: "-invalid-" (try again)`,
+				"  • invalid item: 2 (try again)",
+				"  • unknown command: :mv 1 (try again)",
+				"  • unknown command: : (try again)",
+				"  • a value is required for Hosts (try again)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tags := tc.tags
+			f := fieldInfo{
+				Value:    reflect.ValueOf(&tags).Elem(),
+				Name:     "Hosts",
+				Kind:     tc.kind,
+				Sep:      ",",
+				Required: tc.required,
+			}
+
+			v, err := askForList(f, tc.asker, &Options{})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedValue, v.Interface())
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			if tc.expectedOutputs != nil {
+				assert.Equal(t, tc.expectedOutputs, tc.asker.Outputs[:len(tc.expectedOutputs)])
+			}
+
+			// The field must not be modified
+			assert.Equal(t, tc.tags, tags)
+		})
+	}
+}
+
+func TestAskForList_Types(t *testing.T) {
+	t.Run("URL", func(t *testing.T) {
+		u, _ := url.Parse("https://example.com")
+		urls := []url.URL{*u}
+
+		f := fieldInfo{Value: reflect.ValueOf(&urls).Elem(), Name: "URLs", Kind: KindURL, Sep: ","}
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutString: "https://example.org/a,b"}, {OutString: ""}},
+			},
+		}
+
+		v, err := askForList(f, asker, &Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "      1) https://example.com", asker.Outputs[1])
+		assert.Equal(t, "      2) https://example.org/a,b", asker.Outputs[3])
+		assert.Len(t, v.Interface(), 2)
+	})
+
+	t.Run("Int", func(t *testing.T) {
+		ports := []int{}
+
+		f := fieldInfo{Value: reflect.ValueOf(&ports).Elem(), Name: "Ports", Kind: KindAny, Sep: ","}
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutString: "-1"}, {OutString: "http"}, {OutString: "8080"}, {OutString: ""}},
+			},
+		}
+
+		v, err := askForList(f, asker, &Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{-1, 8080}, v.Interface())
+	})
+
+	t.Run("Secret", func(t *testing.T) {
+		tokens := []string{"token_1"}
+
+		f := fieldInfo{Value: reflect.ValueOf(&tokens).Elem(), Name: "Tokens", Kind: KindSecret, Sep: ","}
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskSecretMocks: []AskSecretMock{{OutString: "token_2"}, {OutString: ""}},
+			},
+		}
+
+		v, err := askForList(f, asker, &Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"token_1", "token_2"}, v.Interface())
+		assert.NotContains(t, asker.Outputs, "      1) token_1")
+		assert.Contains(t, asker.Outputs, "      2) *******")
+	})
+}
+
+func TestRunListCommand(t *testing.T) {
+	tests := []struct {
+		cmd           string
+		expectedError string
+		expectedItems []string
+	}{
+		{"", "unknown command: :", nil},
+		{"rm", "unknown command: :rm", nil},
+		{"rm x", "invalid item: x", nil},
+		{"mv 1 4", "invalid item: 4", nil},
+		{"mv x 1", "invalid item: x", nil},
+		{"clear", "", []string{}},
+		{"rm 2", "", []string{"a", "c"}},
+		{"mv 1 3", "", []string{"b", "c", "a"}},
+		{"mv 3 1", "", []string{"c", "a", "b"}},
+		{"mv 2 2", "", []string{"a", "b", "c"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cmd, func(t *testing.T) {
+			items := []reflect.Value{reflect.ValueOf("a"), reflect.ValueOf("b"), reflect.ValueOf("c")}
+			items, err := runListCommand(items, tc.cmd)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				vals := []string{}
+				for _, item := range items {
+					vals = append(vals, item.String())
+				}
+				assert.Equal(t, tc.expectedItems, vals)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
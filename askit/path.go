@@ -0,0 +1,111 @@
+package askit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	mustExistOpt = "must-exist"
+	dirOpt       = "dir"
+	fileOpt      = "file"
+)
+
+// PathAsker is an optional interface for an Asker to complete paths against the local filesystem.
+type PathAsker interface {
+	AskPath(string) (string, error)
+}
+
+// expandPath expands a leading ~ to the home directory and the environment variables in a path.
+func expandPath(val string) string {
+	val = strings.TrimSpace(val)
+
+	if val == "~" || strings.HasPrefix(val, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			val = home + val[1:]
+		}
+	}
+
+	return os.ExpandEnv(val)
+}
+
+// checkPath checks an expanded path against the constraints of a field.
+// The dir and file constraints are only checked if the path exists, unless the must-exist constraint is also set.
+func checkPath(f fieldInfo, val string) error {
+	info, err := os.Stat(val)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !f.MustExist {
+			return nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("path does not exist")
+		}
+		return err
+	}
+
+	if f.Dir && !info.IsDir() {
+		return errors.New("path is not a directory")
+	}
+
+	if f.File && !info.Mode().IsRegular() {
+		return errors.New("path is not a file")
+	}
+
+	return nil
+}
+
+// completePath completes the last element of a path against the local filesystem.
+// It returns the completed path and the names matching the last element.
+// If there are multiple matches, the path is completed up to their longest common prefix.
+// Names of directories end with a slash.
+func completePath(line string) (string, []string) {
+	if line == "~" {
+		return "~/", nil
+	}
+
+	dir, base := filepath.Split(line)
+
+	lookup := expandPath(dir)
+	if lookup == "" {
+		lookup = "."
+	}
+
+	entries, err := os.ReadDir(lookup)
+	if err != nil {
+		return line, nil
+	}
+
+	matches := []string{}
+	for _, e := range entries {
+		name := e.Name()
+
+		// Hidden entries are only completed if asked for explicitly
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+
+		// Symbolic links to directories are completed as directories
+		if info, err := os.Stat(filepath.Join(lookup, name)); err == nil && info.IsDir() {
+			name += "/"
+		}
+
+		matches = append(matches, name)
+	}
+
+	if len(matches) == 0 {
+		return line, nil
+	}
+
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+
+	return dir + prefix, matches
+}
@@ -0,0 +1,244 @@
+package askit
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPathAsker is an Asker with path completion.
+type mockPathAsker struct {
+	MockAsker
+	AskPathInPrompts []string
+	AskPathOutString string
+	AskPathOutError  error
+}
+
+func (m *mockPathAsker) AskPath(prompt string) (string, error) {
+	m.AskPathInPrompts = append(m.AskPathInPrompts, prompt)
+	return m.AskPathOutString, m.AskPathOutError
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/jane")
+	t.Setenv("CONFIG_DIR", "/etc/app")
+
+	tests := []struct {
+		val          string
+		expectedPath string
+	}{
+		{"", ""},
+		{" ./config.yaml ", "./config.yaml"},
+		{"~", "/home/jane"},
+		{"~/config.yaml", "/home/jane/config.yaml"},
+		{"~jane/config.yaml", "~jane/config.yaml"},
+		{"$CONFIG_DIR/config.yaml", "/etc/app/config.yaml"},
+		{"${HOME}/.config", "/home/jane/.config"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPath, expandPath(tc.val))
+		})
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(file, nil, 0o644))
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name          string
+		f             fieldInfo
+		val           string
+		expectedError string
+	}{
+		{"NoConstraints", fieldInfo{}, missing, ""},
+		{"MustExist", fieldInfo{MustExist: true}, file, ""},
+		{"MustExistMissing", fieldInfo{MustExist: true}, missing, "path does not exist"},
+		{"Dir", fieldInfo{Dir: true}, dir, ""},
+		{"DirMissing", fieldInfo{Dir: true}, missing, ""},
+		{"DirIsFile", fieldInfo{Dir: true}, file, "path is not a directory"},
+		{"DirMustExist", fieldInfo{Dir: true, MustExist: true}, missing, "path does not exist"},
+		{"File", fieldInfo{File: true}, file, ""},
+		{"FileIsDir", fieldInfo{File: true}, dir, "path is not a file"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPath(tc.f, tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestCompletePath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "configs"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "certs.pem"), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), nil, 0o644))
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "configs"), filepath.Join(dir, "link")))
+
+	t.Setenv("HOME", dir)
+	t.Setenv("DATA_DIR", dir)
+
+	tests := []struct {
+		name            string
+		line            string
+		expectedLine    string
+		expectedMatches []string
+	}{
+		{
+			name:            "NoMatch",
+			line:            dir + "/x",
+			expectedLine:    dir + "/x",
+			expectedMatches: nil,
+		},
+		{
+			name:            "DirNotFound",
+			line:            dir + "/missing/c",
+			expectedLine:    dir + "/missing/c",
+			expectedMatches: nil,
+		},
+		{
+			name:            "SingleMatch",
+			line:            dir + "/ce",
+			expectedLine:    dir + "/certs.pem",
+			expectedMatches: []string{"certs.pem"},
+		},
+		{
+			name:            "CommonPrefix",
+			line:            dir + "/con",
+			expectedLine:    dir + "/config",
+			expectedMatches: []string{"config.yaml", "configs/"},
+		},
+		{
+			name:            "AllEntries",
+			line:            dir + "/",
+			expectedLine:    dir + "/",
+			expectedMatches: []string{"certs.pem", "config.yaml", "configs/", "link/"},
+		},
+		{
+			name:            "Hidden",
+			line:            dir + "/.",
+			expectedLine:    dir + "/.env",
+			expectedMatches: []string{".env"},
+		},
+		{
+			name:            "SymbolicLink",
+			line:            dir + "/li",
+			expectedLine:    dir + "/link/",
+			expectedMatches: []string{"link/"},
+		},
+		{
+			name:            "Home",
+			line:            "~",
+			expectedLine:    "~/",
+			expectedMatches: nil,
+		},
+		{
+			name:            "HomePrefix",
+			line:            "~/ce",
+			expectedLine:    "~/certs.pem",
+			expectedMatches: []string{"certs.pem"},
+		},
+		{
+			name:            "EnvVar",
+			line:            "$DATA_DIR/ce",
+			expectedLine:    "$DATA_DIR/certs.pem",
+			expectedMatches: []string{"certs.pem"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			line, matches := completePath(tc.line)
+
+			assert.Equal(t, tc.expectedLine, line)
+			assert.Equal(t, tc.expectedMatches, matches)
+		})
+	}
+}
+
+func TestAsk_Path(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	t.Setenv("HOME", dir)
+
+	type config struct {
+		ConfigFile string `ask:"path, the config file, must-exist, file"`
+		DataDir    string `ask:"path, the data directory, dir"`
+	}
+
+	t.Run("Completion", func(t *testing.T) {
+		c := config{}
+		asker := &mockPathAsker{AskPathOutString: "~/config.yaml"}
+
+		err := Ask(&c, asker, WithStreamlined(), WithAnswers(Answers{"DataDir": "~"}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, config{ConfigFile: file, DataDir: dir}, c)
+		assert.Equal(t, []string{"  • Enter a new value (the config file):"}, asker.AskPathInPrompts)
+	})
+
+	t.Run("Constraints", func(t *testing.T) {
+		c := config{}
+		asker := &MockAsker{
+			AskMocks: []AskMock{
+				{OutString: "~/missing.yaml"}, {OutString: "~"}, {OutString: "~/config.yaml"}, // ConfigFile
+				{OutString: "~/config.yaml"}, {OutString: "~/config.yaml"}, {OutString: "~/config.yaml"}, // DataDir
+			},
+		}
+
+		err := Ask(&c, asker, WithStreamlined())
+
+		assert.EqualError(t, err, "invalid path entered for DataDir: path is not a directory")
+		assert.Equal(t, config{}, c)
+	})
+}
+
+func TestContextAsker_AskPath(t *testing.T) {
+	asker := &mockPathAsker{
+		MockAsker: MockAsker{
+			AskMocks: []AskMock{{OutString: "/etc"}},
+		},
+		AskPathOutString: "/tmp",
+	}
+
+	c := newContextAsker(t.Context(), asker, 0).(*contextAsker)
+
+	val, err := c.AskPath("Path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp", val)
+
+	// Paths are asked for without completion when there is a prompt timeout
+	c = newContextAsker(t.Context(), asker, time.Second).(*contextAsker)
+
+	val, err = c.AskPath("Path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc", val)
+	assert.Equal(t, []string{"Path:"}, asker.AskPathInPrompts)
+}
+
+func TestAskFuncFor(t *testing.T) {
+	asker := &mockPathAsker{AskPathOutString: "/tmp"}
+	f := fieldInfo{Value: reflect.ValueOf(new(string)).Elem(), Kind: KindPath}
+
+	val, err := askFuncFor(f, asker)("Path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp", val)
+}
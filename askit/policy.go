@@ -0,0 +1,125 @@
+package askit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const policyTag = "policy"
+
+// Policy is a set of requirements for secret values such as passwords.
+// A policy can be set for a field using the policy tag (i.e. `policy:"min=12,classes=3,entropy=60"`).
+type Policy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// MinClasses is the minimum number of character classes (lowercase letters, uppercase letters, digits, and symbols).
+	MinClasses int
+	// MinEntropy is the minimum estimated entropy in bits.
+	// The entropy is estimated as the length multiplied by log2 of the size of the character classes used.
+	MinEntropy float64
+}
+
+func parsePolicy(val string) (*Policy, error) {
+	p := new(Policy)
+
+	for _, rule := range strings.Split(val, ",") {
+		name, arg, ok := strings.Cut(strings.TrimSpace(rule), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule: %s", rule)
+		}
+
+		var err error
+
+		switch strings.TrimSpace(name) {
+		case "min":
+			p.MinLength, err = strconv.Atoi(strings.TrimSpace(arg))
+		case "classes":
+			p.MinClasses, err = strconv.Atoi(strings.TrimSpace(arg))
+		case "entropy":
+			p.MinEntropy, err = strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		default:
+			return nil, fmt.Errorf("unknown rule: %s", name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule: %s", rule)
+		}
+	}
+
+	return p, nil
+}
+
+// Check verifies a value against the policy.
+// The error describes every unmet requirement without including the value.
+func (p *Policy) Check(val string) error {
+	length := len([]rune(val))
+	classes, poolSize := characterClasses(val)
+	entropy := estimateEntropy(length, poolSize)
+
+	problems := []string{}
+
+	if length < p.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+
+	if classes < p.MinClasses {
+		problems = append(problems, fmt.Sprintf("must contain at least %d of lowercase letters, uppercase letters, digits, and symbols", p.MinClasses))
+	}
+
+	if entropy < p.MinEntropy {
+		problems = append(problems, fmt.Sprintf("is too predictable (estimated entropy of %.0f bits, at least %.0f bits required)", entropy, p.MinEntropy))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// characterClasses returns the number of character classes used in a value and the total size of them.
+func characterClasses(val string) (int, int) {
+	var lower, upper, digit, symbol bool
+
+	for _, r := range val {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	var classes, poolSize int
+
+	if lower {
+		classes, poolSize = classes+1, poolSize+26
+	}
+	if upper {
+		classes, poolSize = classes+1, poolSize+26
+	}
+	if digit {
+		classes, poolSize = classes+1, poolSize+10
+	}
+	if symbol {
+		classes, poolSize = classes+1, poolSize+33
+	}
+
+	return classes, poolSize
+}
+
+func estimateEntropy(length, poolSize int) float64 {
+	if length == 0 || poolSize == 0 {
+		return 0
+	}
+
+	return float64(length) * math.Log2(float64(poolSize))
+}
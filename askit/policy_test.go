@@ -0,0 +1,126 @@
+package askit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		val            string
+		expectedError  string
+		expectedPolicy *Policy
+	}{
+		{
+			name:          "NoValue",
+			val:           "min",
+			expectedError: "invalid rule: min",
+		},
+		{
+			name:          "InvalidValue",
+			val:           "min=twelve",
+			expectedError: "invalid rule: min=twelve",
+		},
+		{
+			name:          "UnknownRule",
+			val:           "max=64",
+			expectedError: "unknown rule: max",
+		},
+		{
+			name:           "Success",
+			val:            "min=12, classes=3, entropy=60.5",
+			expectedPolicy: &Policy{MinLength: 12, MinClasses: 3, MinEntropy: 60.5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := parsePolicy(tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPolicy, p)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, p)
+			}
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             *Policy
+		val           string
+		expectedError string
+	}{
+		{
+			name:          "TooShort",
+			p:             &Policy{MinLength: 12},
+			val:           "Secret123!",
+			expectedError: "must be at least 12 characters long",
+		},
+		{
+			name:          "TooFewClasses",
+			p:             &Policy{MinClasses: 3},
+			val:           "secretpassword",
+			expectedError: "must contain at least 3 of lowercase letters, uppercase letters, digits, and symbols",
+		},
+		{
+			name:          "TooPredictable",
+			p:             &Policy{MinEntropy: 60},
+			val:           "secret",
+			expectedError: "is too predictable (estimated entropy of 28 bits, at least 60 bits required)",
+		},
+		{
+			name:          "Empty",
+			p:             &Policy{MinLength: 8, MinClasses: 2, MinEntropy: 40},
+			val:           "",
+			expectedError: "must be at least 8 characters long, must contain at least 2 of lowercase letters, uppercase letters, digits, and symbols, is too predictable (estimated entropy of 0 bits, at least 40 bits required)",
+		},
+		{
+			name: "Success",
+			p:    &Policy{MinLength: 12, MinClasses: 4, MinEntropy: 60},
+			val:  "Correct-Horse-42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.Check(tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestCharacterClasses(t *testing.T) {
+	tests := []struct {
+		val              string
+		expectedClasses  int
+		expectedPoolSize int
+	}{
+		{"", 0, 0},
+		{"secret", 1, 26},
+		{"Secret", 2, 52},
+		{"Secret123", 3, 62},
+		{"Secret123!", 4, 95},
+		{"пароль", 1, 26},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			classes, poolSize := characterClasses(tc.val)
+
+			assert.Equal(t, tc.expectedClasses, classes)
+			assert.Equal(t, tc.expectedPoolSize, poolSize)
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package askit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const redacted = "<redacted>"
+
+// Transcript entry types.
+const (
+	EntryAsk         = "ask"
+	EntrySecret      = "secret"
+	EntrySelect      = "select"
+	EntryMultiSelect = "multiselect"
+	EntryEdit        = "edit"
+)
+
+// TranscriptEntry is a prompt and its answer in a recorded session.
+// A transcript is written as one JSON-encoded entry per line.
+type TranscriptEntry struct {
+	Type    string   `json:"type"`
+	Prompt  string   `json:"prompt"`
+	Answer  string   `json:"answer,omitempty"`
+	Choices []string `json:"choices,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Recorder is an Asker that records every prompt and answer of another Asker to a transcript.
+// Secret answers are never recorded.
+// The optional interfaces implemented by the other Asker (ContextAsker, Selector, Editor, and PathAsker) are passed through.
+type Recorder struct {
+	asker Asker
+	enc   *json.Encoder
+
+	// ctxAsker abandons the calls to an Asker not implementing ContextAsker once the context is cancelled.
+	ctxAsker *contextAsker
+}
+
+// NewRecorder creates a new Recorder that records the session of an Asker to a writer.
+func NewRecorder(asker Asker, w io.Writer) *Recorder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	return &Recorder{
+		asker: asker,
+		enc:   enc,
+	}
+}
+
+func (r *Recorder) record(e TranscriptEntry, err error) error {
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	if encErr := r.enc.Encode(e); encErr != nil {
+		return fmt.Errorf("failed to record transcript: %s", encErr)
+	}
+
+	return err
+}
+
+// Output writes a message to the underlying Asker.
+func (r *Recorder) Output(message string) {
+	r.asker.Output(message)
+}
+
+// Ask asks for a value using the underlying Asker and records the prompt and answer.
+func (r *Recorder) Ask(prompt string) (string, error) {
+	ans, err := r.asker.Ask(prompt)
+	return ans, r.record(TranscriptEntry{Type: EntryAsk, Prompt: prompt, Answer: ans}, err)
+}
+
+// AskSecret asks for a secret value using the underlying Asker and records the prompt with the answer redacted.
+func (r *Recorder) AskSecret(prompt string) (string, error) {
+	ans, err := r.asker.AskSecret(prompt)
+	return ans, r.record(TranscriptEntry{Type: EntrySecret, Prompt: prompt, Answer: redacted}, err)
+}
+
+// AskContext asks for a value using the underlying Asker until the context is cancelled and records the prompt and answer.
+func (r *Recorder) AskContext(ctx context.Context, prompt string) (string, error) {
+	ans, err := r.askContext(ctx, prompt, false)
+	return ans, r.record(TranscriptEntry{Type: EntryAsk, Prompt: prompt, Answer: ans}, err)
+}
+
+// AskSecretContext asks for a secret value using the underlying Asker until the context is cancelled and records the prompt with the answer redacted.
+func (r *Recorder) AskSecretContext(ctx context.Context, prompt string) (string, error) {
+	ans, err := r.askContext(ctx, prompt, true)
+	return ans, r.record(TranscriptEntry{Type: EntrySecret, Prompt: prompt, Answer: redacted}, err)
+}
+
+func (r *Recorder) askContext(ctx context.Context, prompt string, secret bool) (string, error) {
+	if ca, ok := r.asker.(ContextAsker); ok {
+		if secret {
+			return ca.AskSecretContext(ctx, prompt)
+		}
+		return ca.AskContext(ctx, prompt)
+	}
+
+	if r.ctxAsker == nil {
+		r.ctxAsker = &contextAsker{asker: r.asker}
+	}
+
+	r.ctxAsker.ctx = ctx
+
+	return r.ctxAsker.call(prompt, secret)
+}
+
+// AskPath asks for a path using the underlying Asker and records the prompt and answer.
+// If the underlying Asker is not a PathAsker, the path is asked for without completion.
+func (r *Recorder) AskPath(prompt string) (string, error) {
+	p, ok := r.asker.(PathAsker)
+	if !ok {
+		return r.Ask(prompt)
+	}
+
+	ans, err := p.AskPath(prompt)
+	return ans, r.record(TranscriptEntry{Type: EntryAsk, Prompt: prompt, Answer: ans}, err)
+}
+
+// Edit edits a text using the underlying Asker and records the text and the edited text.
+// If the underlying Asker is not an Editor, ErrNoEditor is returned.
+func (r *Recorder) Edit(text string) (string, error) {
+	e, ok := r.asker.(Editor)
+	if !ok {
+		return "", ErrNoEditor
+	}
+
+	edited, err := e.Edit(text)
+
+	// The text is read line by line instead
+	if errors.Is(err, ErrNoEditor) {
+		return "", err
+	}
+
+	return edited, r.record(TranscriptEntry{Type: EntryEdit, Prompt: text, Answer: edited}, err)
+}
+
+// selectsByNumber determines whether or not a numbered menu is presented instead of the underlying Selector.
+func (r *Recorder) selectsByNumber() bool {
+	_, ok := asSelector(r.asker)
+	return !ok
+}
+
+// Select asks for one of the options using the underlying Asker and records the prompt and the chosen option.
+// If the underlying Asker is not a Selector, a numbered menu is presented instead.
+func (r *Recorder) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	s, ok := r.asker.(Selector)
+	if !ok {
+		return selectByNumber(r, nil, prompt, options, defaultIndex, false)
+	}
+
+	i, err := s.Select(prompt, options, defaultIndex)
+
+	e := TranscriptEntry{Type: EntrySelect, Prompt: prompt}
+	if err == nil {
+		e.Answer = options[i]
+	}
+
+	return i, r.record(e, err)
+}
+
+// MultiSelect asks for any number of the options using the underlying Asker and records the prompt and the chosen options.
+// If the underlying Asker is not a Selector, a numbered menu is presented instead.
+func (r *Recorder) MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error) {
+	s, ok := r.asker.(Selector)
+	if !ok {
+		return multiSelectByNumber(r, nil, prompt, options, defaultIndices, false)
+	}
+
+	indices, err := s.MultiSelect(prompt, options, defaultIndices)
+
+	e := TranscriptEntry{Type: EntryMultiSelect, Prompt: prompt, Choices: []string{}}
+	for _, i := range indices {
+		e.Choices = append(e.Choices, options[i])
+	}
+
+	return indices, r.record(e, err)
+}
+
+// Replayer is an Asker that answers the prompts from a recorded transcript.
+// Every prompt must match the prompt recorded in the transcript.
+type Replayer struct {
+	entries []TranscriptEntry
+	secrets []string
+	index   int
+}
+
+// NewReplayer creates a new Replayer that reads a transcript from a reader.
+// Since secret answers are not recorded, they are given separately in the order they are asked for.
+func NewReplayer(r io.Reader, secrets ...string) (*Replayer, error) {
+	entries := []TranscriptEntry{}
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e TranscriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid transcript entry on line %d: %s", line, err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Replayer{
+		entries: entries,
+		secrets: secrets,
+	}, nil
+}
+
+// next returns the next entry in the transcript if it matches the type and prompt.
+func (r *Replayer) next(typ, prompt string) (TranscriptEntry, error) {
+	if r.index >= len(r.entries) {
+		return TranscriptEntry{}, fmt.Errorf("unexpected prompt: %q (transcript ended)", prompt)
+	}
+
+	e := r.entries[r.index]
+	if e.Type != typ || e.Prompt != prompt {
+		return TranscriptEntry{}, fmt.Errorf("prompt mismatch at entry %d: expected %s %q, got %s %q", r.index+1, e.Type, e.Prompt, typ, prompt)
+	}
+
+	r.index++
+
+	switch e.Error {
+	case "":
+	case context.Canceled.Error():
+		return e, context.Canceled
+	case context.DeadlineExceeded.Error():
+		return e, context.DeadlineExceeded
+	default:
+		return e, errors.New(e.Error)
+	}
+
+	return e, nil
+}
+
+// peek returns the type of the next entry in the transcript.
+func (r *Replayer) peek() string {
+	if r.index < len(r.entries) {
+		return r.entries[r.index].Type
+	}
+	return ""
+}
+
+// Output discards the message.
+func (r *Replayer) Output(string) {}
+
+// Ask returns the recorded answer for a prompt.
+func (r *Replayer) Ask(prompt string) (string, error) {
+	e, err := r.next(EntryAsk, prompt)
+	return e.Answer, err
+}
+
+// AskSecret returns the next secret for a prompt recorded as a secret prompt.
+func (r *Replayer) AskSecret(prompt string) (string, error) {
+	if _, err := r.next(EntrySecret, prompt); err != nil {
+		return "", err
+	}
+
+	if len(r.secrets) == 0 {
+		return "", fmt.Errorf("no secret provided for prompt: %q", prompt)
+	}
+
+	secret := r.secrets[0]
+	r.secrets = r.secrets[1:]
+
+	return secret, nil
+}
+
+// Edit returns the recorded edited text for a text.
+// If the session was recorded without an editor, ErrNoEditor is returned.
+func (r *Replayer) Edit(text string) (string, error) {
+	if r.peek() != EntryEdit {
+		return "", ErrNoEditor
+	}
+
+	e, err := r.next(EntryEdit, text)
+	return e.Answer, err
+}
+
+// selectsByNumber determines whether or not the session was recorded with a numbered menu.
+func (r *Replayer) selectsByNumber() bool {
+	return r.peek() == EntryAsk
+}
+
+// Select returns the index of the recorded option for a prompt.
+func (r *Replayer) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	// The session was recorded with a numbered menu
+	if r.peek() == EntryAsk {
+		return selectByNumber(r, nil, prompt, options, defaultIndex, false)
+	}
+
+	e, err := r.next(EntrySelect, prompt)
+	if err != nil {
+		return -1, err
+	}
+
+	i := indexOf(options, e.Answer)
+	if i < 0 {
+		return -1, fmt.Errorf("recorded option not found for prompt %q: %s", prompt, e.Answer)
+	}
+
+	return i, nil
+}
+
+// MultiSelect returns the indices of the recorded options for a prompt.
+func (r *Replayer) MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error) {
+	// The session was recorded with a numbered menu
+	if r.peek() == EntryAsk {
+		return multiSelectByNumber(r, nil, prompt, options, defaultIndices, false)
+	}
+
+	e, err := r.next(EntryMultiSelect, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make([]int, len(e.Choices))
+	for j, choice := range e.Choices {
+		i := indexOf(options, choice)
+		if i < 0 {
+			return nil, fmt.Errorf("recorded option not found for prompt %q: %s", prompt, choice)
+		}
+		indices[j] = i
+	}
+
+	return indices, nil
+}
+
+// Done returns an error if any recorded prompt has not been asked for.
+func (r *Replayer) Done() error {
+	if n := len(r.entries) - r.index; n > 0 {
+		return fmt.Errorf("%d recorded prompts not asked for, next: %q", n, r.entries[r.index].Prompt)
+	}
+
+	return nil
+}
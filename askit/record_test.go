@@ -0,0 +1,406 @@
+package askit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type wizard struct {
+	Name   string   `ask:"any, your name"`
+	Token  string   `ask:"secret, your access token"`
+	Env    string   `ask:"select, the environment" options:"dev|staging|prod"`
+	Groups []string `ask:"multiselect, your groups" options:"admin|dev|ops"`
+}
+
+func TestRecorder(t *testing.T) {
+	tests := []struct {
+		name               string
+		asker              Asker
+		expectedTranscript string
+	}{
+		{
+			name: "NumberedMenu",
+			asker: &MockAsker{
+				AskMocks: []AskMock{
+					{OutString: "Y"}, {OutString: "Jane Doe"}, // Name
+					{OutString: "Y"},                   // Token
+					{OutString: "Y"}, {OutString: "3"}, // Env
+					{OutString: "Y"}, {OutString: "1,3"}, // Groups
+				},
+				AskSecretMocks: []AskSecretMock{
+					{OutString: "access_token"}, // Token
+				},
+			},
+			expectedTranscript: `{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"ask","prompt":"  • Enter a new value (your name):","answer":"Jane Doe"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"secret","prompt":"  • Enter a new value (your access token):","answer":"<redacted>"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"ask","prompt":"  • Select an option (the environment):","answer":"3"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"ask","prompt":"  • Select an option (your groups):","answer":"1,3"}
+`,
+		},
+		{
+			name: "Selector",
+			asker: &mockSelector{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "Y"}, {OutString: "Jane Doe"}, // Name
+						{OutString: "Y"}, // Token
+						{OutString: "Y"}, // Env
+						{OutString: "Y"}, // Groups
+					},
+					AskSecretMocks: []AskSecretMock{
+						{OutString: "access_token"}, // Token
+					},
+				},
+				SelectOutIndex:        2,
+				MultiSelectOutIndices: []int{0, 2},
+			},
+			expectedTranscript: `{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"ask","prompt":"  • Enter a new value (your name):","answer":"Jane Doe"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"secret","prompt":"  • Enter a new value (your access token):","answer":"<redacted>"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"select","prompt":"  • Select an option (the environment):","answer":"prod"}
+{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"multiselect","prompt":"  • Select an option (your groups):","choices":["admin","ops"]}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transcript := new(bytes.Buffer)
+			w := wizard{}
+
+			err := Ask(&w, NewRecorder(tc.asker, transcript))
+			assert.NoError(t, err)
+			assert.Equal(t, wizard{"Jane Doe", "access_token", "prod", []string{"admin", "ops"}}, w)
+			assert.Equal(t, tc.expectedTranscript, transcript.String())
+			assert.NotContains(t, transcript.String(), "access_token")
+
+			// Replay the recorded session
+			replayer, err := NewReplayer(transcript, "new_token")
+			assert.NoError(t, err)
+
+			w = wizard{}
+			err = Ask(&w, replayer)
+			assert.NoError(t, err)
+			assert.Equal(t, wizard{"Jane Doe", "new_token", "prod", []string{"admin", "ops"}}, w)
+			assert.NoError(t, replayer.Done())
+		})
+	}
+}
+
+func TestRecorder_Error(t *testing.T) {
+	transcript := new(bytes.Buffer)
+	recorder := NewRecorder(&MockAsker{
+		AskMocks: []AskMock{{OutError: errors.New("io error")}},
+	}, transcript)
+
+	_, err := recorder.Ask("Name:")
+	assert.EqualError(t, err, "io error")
+	assert.Equal(t, `{"type":"ask","prompt":"Name:","error":"io error"}`+"\n", transcript.String())
+
+	replayer, err := NewReplayer(transcript)
+	assert.NoError(t, err)
+
+	_, err = replayer.Ask("Name:")
+	assert.EqualError(t, err, "io error")
+}
+
+func TestRecorder_ContextAsker(t *testing.T) {
+	t.Run("ContextAsker", func(t *testing.T) {
+		transcript := new(bytes.Buffer)
+		asker := &mockContextAsker{}
+		recorder := NewRecorder(asker, transcript)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := recorder.AskSecretContext(ctx, "Token:")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, []string{"Token:"}, asker.Prompts)
+		assert.Equal(t, `{"type":"secret","prompt":"Token:","answer":"<redacted>","error":"context deadline exceeded"}`+"\n", transcript.String())
+
+		replayer, err := NewReplayer(transcript)
+		assert.NoError(t, err)
+
+		_, err = replayer.AskSecret("Token:")
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("NotContextAsker", func(t *testing.T) {
+		transcript := new(bytes.Buffer)
+		asker := &chanAsker{answers: make(chan string)}
+		recorder := NewRecorder(asker, transcript)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := recorder.AskContext(ctx, "Name:")
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		go func() {
+			asker.answers <- "late"
+			asker.answers <- "Jane Doe"
+		}()
+
+		ans, err := recorder.AskContext(context.Background(), "Name:")
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane Doe", ans)
+		assert.False(t, asker.concurrent)
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		type config struct {
+			Name string `ask:"any, your name"`
+		}
+
+		transcript := new(bytes.Buffer)
+		recorder := NewRecorder(&mockContextAsker{}, transcript)
+
+		c := config{Name: "Jane Doe"}
+		err := AskContext(context.Background(), &c, recorder, WithPromptTimeout(10*time.Millisecond, true))
+		assert.NoError(t, err)
+		assert.Equal(t, config{Name: "Jane Doe"}, c)
+
+		// Replay the recorded session
+		replayer, err := NewReplayer(transcript)
+		assert.NoError(t, err)
+
+		err = AskContext(context.Background(), &c, replayer, WithPromptTimeout(time.Second, true))
+		assert.NoError(t, err)
+		assert.Equal(t, config{Name: "Jane Doe"}, c)
+		assert.NoError(t, replayer.Done())
+	})
+}
+
+func TestRecorder_AskPath(t *testing.T) {
+	transcript := new(bytes.Buffer)
+	asker := &mockPathAsker{AskPathOutString: "/etc/app"}
+	recorder := NewRecorder(asker, transcript)
+
+	ans, err := recorder.AskPath("Path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/app", ans)
+	assert.Equal(t, []string{"Path:"}, asker.AskPathInPrompts)
+
+	// Not a PathAsker
+	recorder = NewRecorder(&MockAsker{AskMocks: []AskMock{{OutString: "/var/app"}}}, transcript)
+
+	ans, err = recorder.AskPath("Path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "/var/app", ans)
+
+	assert.Equal(t, `{"type":"ask","prompt":"Path:","answer":"/etc/app"}
+{"type":"ask","prompt":"Path:","answer":"/var/app"}
+`, transcript.String())
+}
+
+func TestRecorder_Edit(t *testing.T) {
+	type config struct {
+		Query string `ask:"editor, the query"`
+	}
+
+	asker := &mockEditor{
+		MockAsker: MockAsker{
+			AskMocks: []AskMock{{OutString: "Y"}},
+		},
+		EditOutText: "# Enter a new value for Query (the query).\n# These two lines are ignored.\nSELECT 2;\n",
+	}
+
+	transcript := new(bytes.Buffer)
+	c := config{Query: "SELECT 1;"}
+	err := Ask(&c, NewRecorder(asker, transcript))
+	assert.NoError(t, err)
+	assert.Equal(t, config{Query: "SELECT 2;"}, c)
+	assert.Equal(t, `{"type":"ask","prompt":"  • Would you like to enter a value [Y]?","answer":"Y"}
+{"type":"edit","prompt":"# Enter a new value for Query (the query).\n# These two lines are ignored.\nSELECT 1;","answer":"# Enter a new value for Query (the query).\n# These two lines are ignored.\nSELECT 2;\n"}
+`, transcript.String())
+
+	// Replay the recorded session
+	replayer, err := NewReplayer(transcript)
+	assert.NoError(t, err)
+
+	c = config{Query: "SELECT 1;"}
+	err = Ask(&c, replayer)
+	assert.NoError(t, err)
+	assert.Equal(t, config{Query: "SELECT 2;"}, c)
+	assert.NoError(t, replayer.Done())
+
+	// Without an editor, nothing is recorded
+	transcript.Reset()
+	recorder := NewRecorder(&mockEditor{EditOutErr: ErrNoEditor}, transcript)
+
+	_, err = recorder.Edit("text")
+	assert.Equal(t, ErrNoEditor, err)
+
+	recorder = NewRecorder(&MockAsker{}, transcript)
+
+	_, err = recorder.Edit("text")
+	assert.Equal(t, ErrNoEditor, err)
+	assert.Empty(t, transcript.String())
+
+	// The session was recorded without an editor
+	replayer, err = NewReplayer(strings.NewReader(`{"type":"ask","prompt":"Line:","answer":"SELECT 1;"}`))
+	assert.NoError(t, err)
+
+	_, err = replayer.Edit("text")
+	assert.Equal(t, ErrNoEditor, err)
+}
+
+func TestNewReplayer(t *testing.T) {
+	tests := []struct {
+		name          string
+		transcript    string
+		expectedError string
+	}{
+		{
+			name:          "InvalidEntry",
+			transcript:    "{\"type\":\"ask\",\"prompt\":\"Name:\"}\n\nnot json\n",
+			expectedError: "invalid transcript entry on line 3: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:       "Success",
+			transcript: "{\"type\":\"ask\",\"prompt\":\"Name:\"}\n\n{\"type\":\"secret\",\"prompt\":\"Token:\"}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			replayer, err := NewReplayer(strings.NewReader(tc.transcript))
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Len(t, replayer.entries, 2)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, replayer)
+			}
+		})
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	transcript := `{"type":"ask","prompt":"Name:","answer":"Jane Doe"}
+{"type":"secret","prompt":"Token:","answer":"<redacted>"}
+{"type":"select","prompt":"Env:","answer":"qa"}
+{"type":"multiselect","prompt":"Groups:","choices":["admin","qa"]}
+`
+
+	tests := []struct {
+		name          string
+		secrets       []string
+		replay        func(*Replayer) error
+		expectedError string
+	}{
+		{
+			name: "PromptMismatch",
+			replay: func(r *Replayer) error {
+				_, err := r.Ask("Email:")
+				return err
+			},
+			expectedError: `prompt mismatch at entry 1: expected ask "Name:", got ask "Email:"`,
+		},
+		{
+			name: "TypeMismatch",
+			replay: func(r *Replayer) error {
+				_, err := r.AskSecret("Name:")
+				return err
+			},
+			expectedError: `prompt mismatch at entry 1: expected ask "Name:", got secret "Name:"`,
+		},
+		{
+			name: "NoSecret",
+			replay: func(r *Replayer) error {
+				_, _ = r.Ask("Name:")
+				_, err := r.AskSecret("Token:")
+				return err
+			},
+			expectedError: `no secret provided for prompt: "Token:"`,
+		},
+		{
+			name:    "OptionNotFound",
+			secrets: []string{"token"},
+			replay: func(r *Replayer) error {
+				_, _ = r.Ask("Name:")
+				_, _ = r.AskSecret("Token:")
+				_, err := r.Select("Env:", []string{"dev", "prod"}, -1)
+				return err
+			},
+			expectedError: `recorded option not found for prompt "Env:": qa`,
+		},
+		{
+			name:    "MultiOptionNotFound",
+			secrets: []string{"token"},
+			replay: func(r *Replayer) error {
+				_, _ = r.Ask("Name:")
+				_, _ = r.AskSecret("Token:")
+				_, _ = r.Select("Env:", []string{"dev", "qa"}, -1)
+				_, err := r.MultiSelect("Groups:", []string{"admin", "dev"}, nil)
+				return err
+			},
+			expectedError: `recorded option not found for prompt "Groups:": qa`,
+		},
+		{
+			name: "NotDone",
+			replay: func(r *Replayer) error {
+				_, _ = r.Ask("Name:")
+				return r.Done()
+			},
+			expectedError: `3 recorded prompts not asked for, next: "Token:"`,
+		},
+		{
+			name: "TranscriptEnded",
+			replay: func(r *Replayer) error {
+				r.index = len(r.entries)
+				_, err := r.Ask("Name:")
+				return err
+			},
+			expectedError: `unexpected prompt: "Name:" (transcript ended)`,
+		},
+		{
+			name:    "Success",
+			secrets: []string{"token"},
+			replay: func(r *Replayer) error {
+				if ans, _ := r.Ask("Name:"); ans != "Jane Doe" {
+					return errors.New("unexpected answer: " + ans)
+				}
+				if ans, _ := r.AskSecret("Token:"); ans != "token" {
+					return errors.New("unexpected secret: " + ans)
+				}
+				if i, _ := r.Select("Env:", []string{"dev", "qa"}, -1); i != 1 {
+					return errors.New("unexpected option")
+				}
+				if indices, _ := r.MultiSelect("Groups:", []string{"qa", "admin"}, nil); len(indices) != 2 || indices[0] != 1 || indices[1] != 0 {
+					return errors.New("unexpected options")
+				}
+				return r.Done()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			replayer, err := NewReplayer(strings.NewReader(transcript), tc.secrets...)
+			assert.NoError(t, err)
+
+			err = tc.replay(replayer)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package askit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gardenbed/charm/ui"
+)
+
+// Message identifies a text shown to the user when asking for values.
+type Message string
+
+const (
+	// MessageHeader is the header shown for each field (args: field name).
+	MessageHeader Message = "header"
+	// MessageSectionHeader is the header shown for a section of fields (args: section title).
+	MessageSectionHeader Message = "section-header"
+	// MessageSectionGate asks whether or not to configure an optional section (args: section title).
+	MessageSectionGate Message = "section-gate"
+	// MessageCurrentValue shows the current value of a field (args: value).
+	MessageCurrentValue Message = "current-value"
+	// MessageEnterGate asks whether or not to enter a value for a field.
+	MessageEnterGate Message = "enter-gate"
+	// MessageEnterValue asks for a new value when there is no description.
+	MessageEnterValue Message = "enter-value"
+	// MessageEnterValueHint asks for a new value (args: description).
+	MessageEnterValueHint Message = "enter-value-hint"
+	// MessageConfirmValue asks for a new value again for confirmation.
+	MessageConfirmValue Message = "confirm-value"
+	// MessageClearConfirm asks for confirmation before clearing a secret (args: field name).
+	MessageClearConfirm Message = "clear-confirm"
+	// MessageSelectOption asks for a choice when there is no description.
+	MessageSelectOption Message = "select-option"
+	// MessageSelectOptionHint asks for a choice (args: description).
+	MessageSelectOptionHint Message = "select-option-hint"
+	// MessageTryAgain shows an invalid input error (args: error).
+	MessageTryAgain Message = "try-again"
+	// MessageValueRequired is the error for a required field left empty (args: field name).
+	MessageValueRequired Message = "value-required"
+	// MessageValuesMismatch is the error for a confirmation not matching the value (args: field name).
+	MessageValuesMismatch Message = "values-mismatch"
+	// MessageListHelp explains how to edit a list.
+	MessageListHelp Message = "list-help"
+	// MessageAddItem asks for a list item when there is no description.
+	MessageAddItem Message = "add-item"
+	// MessageAddItemHint asks for a list item (args: description).
+	MessageAddItemHint Message = "add-item-hint"
+	// MessageItem shows a list or menu item (args: number, value).
+	MessageItem Message = "item"
+	// MessageNoOption is the error for a numbered menu left empty with no option selected by default.
+	MessageNoOption Message = "no-option"
+	// MessageInvalidOption is the error for an invalid answer to a numbered menu (args: answer, number of options).
+	MessageInvalidOption Message = "invalid-option"
+	// MessageSelectHelp explains how to select an option from a list in a terminal.
+	MessageSelectHelp Message = "select-help"
+	// MessageMultiSelectHelp explains how to select options from a list with checkboxes in a terminal.
+	MessageMultiSelectHelp Message = "multiselect-help"
+	// MessageMultilineHelp explains how to enter a multiline value (args: description, terminator).
+	MessageMultilineHelp Message = "multiline-help"
+	// MessageMultilineLine is the prompt for each line of a multiline value.
+	MessageMultilineLine Message = "multiline-line"
+	// MessageEditorHeader is the comment at the top of an editor file (args: field name, description).
+	MessageEditorHeader Message = "editor-header"
+	// MessageReviewHeader is the header shown before reviewing the changes.
+	MessageReviewHeader Message = "review-header"
+	// MessageReviewChange shows a changed field (args: field name, old value, new value).
+	MessageReviewChange Message = "review-change"
+	// MessageReviewNone is shown in place of a zero value when reviewing the changes.
+	MessageReviewNone Message = "review-none"
+	// MessageReviewConfirm asks whether or not to save the changes.
+	MessageReviewConfirm Message = "review-confirm"
+	// MessageTimeoutFallback shows a timed-out prompt falling back to the current value (args: error).
+	MessageTimeoutFallback Message = "timeout-fallback"
+	// MessageYes is the answer for accepting a yes/no question.
+	// An answer is accepted if it starts with the first letter of this message (case-insensitive).
+	MessageYes Message = "yes"
+)
+
+// Catalog maps messages to format strings.
+// It can be used for translating the messages.
+type Catalog map[Message]string
+
+// Styles maps messages to styles.
+type Styles map[Message]ui.Style
+
+// DefaultCatalog is the catalog of messages in English.
+var DefaultCatalog = Catalog{
+	MessageHeader:           "%s",
+	MessageSectionHeader:    "%s",
+	MessageSectionGate:      "  • Configure %s [y/N]?",
+	MessageCurrentValue:     "  • Current value: %s",
+	MessageEnterGate:        "  • Would you like to enter a value [Y]?",
+	MessageEnterValue:       "  Enter a new value:",
+	MessageEnterValueHint:   "  • Enter a new value (%s):",
+	MessageConfirmValue:     "  • Confirm the new value:",
+	MessageClearConfirm:     "  • Are you sure you want to clear %s [y/N]?",
+	MessageSelectOption:     "  • Select an option:",
+	MessageSelectOptionHint: "  • Select an option (%s):",
+	MessageTryAgain:         "  • %s (try again)",
+	MessageValueRequired:    "a value is required for %s",
+	MessageValuesMismatch:   "the values entered for %s do not match",
+	MessageListHelp:         "  • Enter one item per line and an empty line to finish (:rm N to remove, :mv N M to move, :clear to remove all)",
+	MessageAddItem:          "  • Add an item:",
+	MessageAddItemHint:      "  • Add an item (%s):",
+	MessageItem:             "      %d) %s",
+	MessageNoOption:         "no option selected",
+	MessageInvalidOption:    "invalid option: %s (enter a number between 1 and %d)",
+	MessageSelectHelp:       "↑/↓ to move, enter to select",
+	MessageMultiSelectHelp:  "↑/↓ to move, space to toggle, enter to confirm",
+	MessageMultilineHelp:    "  • Enter a new value (%s) and a line with only %q to finish:",
+	MessageMultilineLine:    "   ",
+	MessageEditorHeader:     "# Enter a new value for %s (%s).\n# These two lines are ignored.",
+	MessageReviewHeader:     "Review changes",
+	MessageReviewChange:     "  • %s: %s → %s",
+	MessageReviewNone:       "(none)",
+	MessageReviewConfirm:    "  • Would you like to save the changes [Y/n]?",
+	MessageTimeoutFallback:  "  • %s (keeping the current value)",
+	MessageYes:              "Y",
+}
+
+// DefaultStyles are the styles used by default.
+var DefaultStyles = Styles{
+	MessageHeader:        ui.Style{ui.Bold},
+	MessageSectionHeader: ui.Style{ui.Bold, ui.Underline},
+	MessageReviewHeader:  ui.Style{ui.Bold},
+}
+
+// Renderer renders the messages shown to the user when asking for values.
+type Renderer interface {
+	Render(msg Message, args ...interface{}) string
+}
+
+// renderer renders messages using a catalog and styles.
+type renderer struct {
+	catalog Catalog
+	styles  Styles
+}
+
+// NewRenderer creates a Renderer from a catalog and styles.
+// Messages missing from the catalog are taken from DefaultCatalog.
+// If styles is nil, DefaultStyles are used.
+// Styles are not applied if the NO_COLOR environment variable is set.
+func NewRenderer(catalog Catalog, styles Styles) Renderer {
+	if styles == nil {
+		styles = DefaultStyles
+	}
+
+	return &renderer{
+		catalog: catalog,
+		styles:  styles,
+	}
+}
+
+func (r *renderer) Render(msg Message, args ...interface{}) string {
+	format, ok := r.catalog[msg]
+	if !ok {
+		format = DefaultCatalog[msg]
+	}
+
+	// See https://no-color.org
+	if style, ok := r.styles[msg]; ok && os.Getenv("NO_COLOR") == "" {
+		return style.Sprintf(format, args...)
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+var defaultRenderer = NewRenderer(nil, nil)
+
+// render renders a message using the renderer from the options.
+// If there are no options, the default renderer is used.
+func (o *Options) render(msg Message, args ...interface{}) string {
+	if o == nil || o.Renderer == nil {
+		return defaultRenderer.Render(msg, args...)
+	}
+
+	return o.Renderer.Render(msg, args...)
+}
+
+// isYes determines whether or not an answer accepts a yes/no question.
+func (o *Options) isYes(ans string) bool {
+	yes := []rune(o.render(MessageYes))
+	runes := []rune(ans)
+
+	if len(runes) == 0 || len(yes) == 0 {
+		return false
+	}
+
+	return strings.EqualFold(string(runes[0]), string(yes[0]))
+}
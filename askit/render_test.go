@@ -0,0 +1,172 @@
+package askit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/charm/ui"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name           string
+		catalog        Catalog
+		styles         Styles
+		noColor        string
+		msg            Message
+		args           []interface{}
+		expectedString string
+	}{
+		{
+			name:           "Default",
+			msg:            MessageCurrentValue,
+			args:           []interface{}{"info"},
+			expectedString: "  • Current value: info",
+		},
+		{
+			name:           "DefaultStyle",
+			msg:            MessageHeader,
+			args:           []interface{}{"Name"},
+			expectedString: "\033[1mName\033[0m",
+		},
+		{
+			name:           "NoColor",
+			noColor:        "1",
+			msg:            MessageHeader,
+			args:           []interface{}{"Name"},
+			expectedString: "Name",
+		},
+		{
+			name:           "Translated",
+			catalog:        Catalog{MessageCurrentValue: "  • Aktueller Wert: %s"},
+			msg:            MessageCurrentValue,
+			args:           []interface{}{"info"},
+			expectedString: "  • Aktueller Wert: info",
+		},
+		{
+			name:           "MissingTranslation",
+			catalog:        Catalog{MessageCurrentValue: "  • Aktueller Wert: %s"},
+			msg:            MessageTryAgain,
+			args:           []interface{}{"invalid value"},
+			expectedString: "  • invalid value (try again)",
+		},
+		{
+			name:           "CustomStyles",
+			styles:         Styles{MessageTryAgain: ui.Red},
+			msg:            MessageTryAgain,
+			args:           []interface{}{"invalid value"},
+			expectedString: "\033[31m  • invalid value (try again)\033[0m",
+		},
+		{
+			name:           "EmptyStyles",
+			styles:         Styles{},
+			msg:            MessageHeader,
+			args:           []interface{}{"Name"},
+			expectedString: "Name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tc.noColor)
+
+			r := NewRenderer(tc.catalog, tc.styles)
+			s := r.Render(tc.msg, tc.args...)
+
+			assert.Equal(t, tc.expectedString, s)
+		})
+	}
+}
+
+func TestOptions_isYes(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *Options
+		ans         string
+		expectedYes bool
+	}{
+		{"Empty", &Options{}, "", false},
+		{"Yes", &Options{}, "yes", true},
+		{"No", &Options{}, "N", false},
+		{"Translated", &Options{Renderer: NewRenderer(Catalog{MessageYes: "Ja"}, nil)}, "j", true},
+		{"TranslatedNo", &Options{Renderer: NewRenderer(Catalog{MessageYes: "Ja"}, nil)}, "Y", false},
+		{"Unicode", &Options{Renderer: NewRenderer(Catalog{MessageYes: "Да"}, nil)}, "да", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedYes, tc.opts.isYes(tc.ans))
+		})
+	}
+}
+
+func TestAsk_Renderer(t *testing.T) {
+	type config struct {
+		Name  string `ask:"any, your name"`
+		Email string `ask:"email, your email address"`
+	}
+
+	catalog := Catalog{
+		MessageHeader:         "[%s]",
+		MessageCurrentValue:   "  • Aktueller Wert: %s",
+		MessageEnterGate:      "  • Möchten Sie einen Wert eingeben [J]?",
+		MessageEnterValueHint: "  • Geben Sie einen neuen Wert ein (%s):",
+		MessageTryAgain:       "  • %s (erneut versuchen)",
+		MessageYes:            "J",
+	}
+
+	c := config{Name: "Jane Doe"}
+	asker := &mockOutputAsker{
+		MockAsker: MockAsker{
+			AskMocks: []AskMock{
+				{OutString: "Nein"},                                                     // Name
+				{OutString: "Ja"}, {OutString: "jane"}, {OutString: "jane@example.com"}, // Email
+			},
+		},
+	}
+
+	err := Ask(&c, asker, WithRenderer(NewRenderer(catalog, Styles{})))
+
+	assert.NoError(t, err)
+	assert.Equal(t, config{Name: "Jane Doe", Email: "jane@example.com"}, c)
+	assert.Equal(t, "  • Möchten Sie einen Wert eingeben [J]?", asker.AskMocks[0].InPrompt)
+	assert.Equal(t, "  • Geben Sie einen neuen Wert ein (your email address):", asker.AskMocks[2].InPrompt)
+	assert.Equal(t, []string{
+		"[Name]",
+		"  • Aktueller Wert: Jane Doe",
+		"[Email]",
+		"  • invalid email address entered for Email: mail: missing '@' or angle-addr (erneut versuchen)",
+	}, asker.Outputs)
+}
+
+func TestAsk_Renderer_Menu(t *testing.T) {
+	type config struct {
+		Env     string   `ask:"select, the environment" options:"dev|prod"`
+		Regions []string `ask:"multiselect, the regions" options:"us|eu"`
+	}
+
+	catalog := Catalog{
+		MessageItem:          "    [%d] %s",
+		MessageNoOption:      "keine Option ausgewählt",
+		MessageInvalidOption: "ungültige Option: %s (1-%d)",
+		MessageTryAgain:      "  • %s (erneut versuchen)",
+	}
+
+	c := config{}
+	out := new(bytes.Buffer)
+
+	// The numbered menu of a Terminal is presented using the renderer from the options
+	asker := NewTerminal(strings.NewReader("Y\n\nx\n2\nY\n1,3\n1\n"), out)
+	err := Ask(&c, asker, WithRenderer(NewRenderer(catalog, Styles{})))
+
+	assert.NoError(t, err)
+	assert.Equal(t, config{Env: "prod", Regions: []string{"us"}}, c)
+	assert.Equal(t, 3, strings.Count(out.String(), "    [1] dev\n    [2] prod\n"))
+	assert.Equal(t, 2, strings.Count(out.String(), "    [1] us\n    [2] eu\n"))
+	assert.Contains(t, out.String(), "  • keine Option ausgewählt (erneut versuchen)\n")
+	assert.Contains(t, out.String(), "  • ungültige Option: x (1-2) (erneut versuchen)\n")
+	assert.Contains(t, out.String(), "  • ungültige Option: 3 (1-2) (erneut versuchen)\n")
+}
@@ -0,0 +1,58 @@
+package askit
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrDiscarded is returned when the changes are not confirmed after review.
+var ErrDiscarded = errors.New("changes discarded")
+
+// reviewChanges shows the changed fields and asks for confirmation.
+func reviewChanges(orig, shadow reflect.Value, asker Asker, opts *Options) error {
+	changes := []string{}
+
+	err := iterateOnFields("", shadow, func(f fieldInfo) error {
+		old := f
+		old.Value, _, _ = lookupPath(orig, f.Name)
+
+		if !reflect.DeepEqual(old.Value.Interface(), f.Value.Interface()) {
+			changes = append(changes, opts.render(MessageReviewChange, f.Name, formatReviewValue(old, opts), formatReviewValue(f, opts)))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	asker.Output(opts.render(MessageReviewHeader))
+	for _, change := range changes {
+		asker.Output(change)
+	}
+
+	ans, err := asker.Ask(opts.render(MessageReviewConfirm))
+	if err != nil {
+		return err
+	}
+
+	// The changes are saved by default
+	if ans != "" && !opts.isYes(ans) {
+		return ErrDiscarded
+	}
+
+	return nil
+}
+
+func formatReviewValue(f fieldInfo, opts *Options) string {
+	if f.Value.IsZero() {
+		return opts.render(MessageReviewNone)
+	}
+
+	return formatValue(f)
+}
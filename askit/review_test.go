@@ -0,0 +1,177 @@
+package askit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOutputAsker struct {
+	MockAsker
+	Outputs []string
+}
+
+func (m *mockOutputAsker) Output(message string) {
+	m.Outputs = append(m.Outputs, message)
+}
+
+func TestAsk_Transactional(t *testing.T) {
+	type account struct {
+		Name  string `ask:"any, your name"`
+		Email string `ask:"email, your email address"`
+	}
+
+	a := account{Name: "Jane Doe"}
+	asker := &MockAsker{
+		AskMocks: []AskMock{
+			{OutString: "Y"}, {OutString: "John Doe"}, // Name
+			{OutString: "Y"}, {OutError: errors.New("io error")}, // Email
+		},
+	}
+
+	err := Ask(&a, asker)
+
+	assert.EqualError(t, err, "io error")
+	assert.Equal(t, account{Name: "Jane Doe"}, a)
+}
+
+func TestAsk_Review(t *testing.T) {
+	type account struct {
+		Name  string   `ask:"any, your name"`
+		Email string   `ask:"email, your email address"`
+		Token string   `ask:"secret, your access token"`
+		Tags  []string `ask:"any, your tags"`
+	}
+
+	tests := []struct {
+		name            string
+		asker           *mockOutputAsker
+		expectedError   string
+		expectedAccount account
+		expectedOutputs []string
+	}{
+		{
+			name: "NoChanges",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "N"}, // Name
+						{OutString: "N"}, // Email
+						{OutString: "N"}, // Token
+						{OutString: "N"}, // Tags
+					},
+				},
+			},
+			expectedAccount: account{Name: "Jane Doe", Token: "access_token"},
+		},
+		{
+			name: "ConfirmFails",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "Y"}, {OutString: "John Doe"}, // Name
+						{OutString: "N"},                   // Email
+						{OutString: "N"},                   // Token
+						{OutString: "N"},                   // Tags
+						{OutError: errors.New("io error")}, // Confirm
+					},
+				},
+			},
+			expectedError:   "io error",
+			expectedAccount: account{Name: "Jane Doe", Token: "access_token"},
+			expectedOutputs: []string{
+				"\033[1mReview changes\033[0m",
+				"  • Name: Jane Doe → John Doe",
+			},
+		},
+		{
+			name: "Discarded",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "Y"}, {OutString: "John Doe"}, // Name
+						{OutString: "N"}, // Email
+						{OutString: "N"}, // Token
+						{OutString: "N"}, // Tags
+						{OutString: "n"}, // Confirm
+					},
+				},
+			},
+			expectedError:   "changes discarded",
+			expectedAccount: account{Name: "Jane Doe", Token: "access_token"},
+			expectedOutputs: []string{
+				"\033[1mReview changes\033[0m",
+				"  • Name: Jane Doe → John Doe",
+			},
+		},
+		{
+			name: "ConfirmedByDefault",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "Y"}, {OutString: "John Doe"}, // Name
+						{OutString: "N"}, // Email
+						{OutString: "N"}, // Token
+						{OutString: "N"}, // Tags
+						{OutString: ""},  // Confirm
+					},
+				},
+			},
+			expectedAccount: account{Name: "John Doe", Token: "access_token"},
+			expectedOutputs: []string{
+				"\033[1mReview changes\033[0m",
+				"  • Name: Jane Doe → John Doe",
+			},
+		},
+		{
+			name: "Confirmed",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "N"},                                      // Name
+						{OutString: "Y"}, {OutString: "john.doe@example.com"}, // Email
+						{OutString: "Y"},                     // Token
+						{OutString: "Y"}, {OutString: "a,b"}, // Tags
+						{OutString: "Y"}, // Confirm
+					},
+					AskSecretMocks: []AskSecretMock{
+						{OutString: "new_token"}, // Token
+					},
+				},
+			},
+			expectedAccount: account{Name: "Jane Doe", Email: "john.doe@example.com", Token: "new_token", Tags: []string{"a", "b"}},
+			expectedOutputs: []string{
+				"\033[1mReview changes\033[0m",
+				"  • Email: (none) → john.doe@example.com",
+				"  • Token: ******* → *******",
+				"  • Tags: (none) → a,b",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := account{Name: "Jane Doe", Token: "access_token"}
+			err := Ask(&a, tc.asker, WithReview())
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			// Only the review outputs
+			var outputs []string
+			for i, out := range tc.asker.Outputs {
+				if out == "\033[1mReview changes\033[0m" {
+					outputs = tc.asker.Outputs[i:]
+					break
+				}
+			}
+
+			assert.Equal(t, tc.expectedAccount, a)
+			assert.Equal(t, tc.expectedOutputs, outputs)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package askit
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	sectionTag  = "ask-section"
+	orderTag    = "order"
+	optionalOpt = "optional"
+)
+
+// section is a group of fields from a nested struct with a header.
+type section struct {
+	Parent   *section
+	Title    string
+	Optional bool
+}
+
+// parseSection parses the ask-section tag of a nested struct (i.e. ask-section:"Proxy settings, optional").
+// If no title is provided, the path of the nested struct is used.
+func parseSection(name, val string, parent *section) *section {
+	subs := strings.Split(val, ",")
+
+	s := &section{
+		Parent: parent,
+		Title:  strings.TrimSpace(subs[0]),
+	}
+
+	if s.Title == "" {
+		s.Title = name
+	}
+
+	for _, opt := range subs[1:] {
+		if strings.TrimSpace(opt) == optionalOpt {
+			s.Optional = true
+		}
+	}
+
+	return s
+}
+
+// enterSection shows the headers of a section and its parent sections the first time they are entered.
+// For an optional section, it asks whether or not to configure the section.
+// It returns false if the section or any of its parent sections is skipped.
+func enterSection(s *section, asker Asker, opts *Options, entered map[*section]bool) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+
+	if ok, err := enterSection(s.Parent, asker, opts, entered); !ok || err != nil {
+		return ok, err
+	}
+
+	if ok, seen := entered[s]; seen {
+		return ok, nil
+	}
+
+	asker.Output(opts.render(MessageSectionHeader, s.Title))
+
+	if s.Optional {
+		// The section is skipped if asking fails, so it is not asked again for the next fields
+		entered[s] = false
+
+		ans, err := asker.Ask(opts.render(MessageSectionGate, s.Title))
+		if err != nil {
+			return false, err
+		}
+
+		if !opts.isYes(ans) {
+			return false, nil
+		}
+	}
+
+	entered[s] = true
+
+	return true, nil
+}
+
+// sortFields returns the indices of the fields of a struct sorted by their order tags.
+// Fields without an order tag have the order zero, and fields with the same order keep their declaration order.
+func sortFields(prefix string, vStruct reflect.Value) ([]int, error) {
+	n := vStruct.NumField()
+	indices := make([]int, n)
+	orders := make([]int, n)
+
+	for i := range n {
+		indices[i] = i
+
+		f := vStruct.Type().Field(i)
+		if val := f.Tag.Get(orderTag); val != "" {
+			order, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil {
+				name := f.Name
+				if prefix != "" {
+					name = prefix + "." + name
+				}
+				return nil, fmt.Errorf("invalid %s tag for %s: %s", orderTag, name, val)
+			}
+			orders[i] = order
+		}
+	}
+
+	sort.SliceStable(indices, func(a, b int) bool {
+		return orders[indices[a]] < orders[indices[b]]
+	})
+
+	return indices, nil
+}
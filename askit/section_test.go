@@ -0,0 +1,231 @@
+package askit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSection(t *testing.T) {
+	parent := &section{Title: "Server"}
+
+	tests := []struct {
+		name            string
+		val             string
+		expectedSection *section
+	}{
+		{"Empty", "", &section{Parent: parent, Title: "Server.Proxy"}},
+		{"Title", "Proxy settings", &section{Parent: parent, Title: "Proxy settings"}},
+		{"Optional", " Proxy settings , optional", &section{Parent: parent, Title: "Proxy settings", Optional: true}},
+		{"NoTitle", ",optional", &section{Parent: parent, Title: "Server.Proxy", Optional: true}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSection, parseSection("Server.Proxy", tc.val, parent))
+		})
+	}
+}
+
+func TestSortFields(t *testing.T) {
+	t.Run("InvalidOrder", func(t *testing.T) {
+		s := struct {
+			Name string `order:"first"`
+		}{}
+
+		_, err := sortFields("Contact", reflect.ValueOf(s))
+		assert.EqualError(t, err, "invalid order tag for Contact.Name: first")
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		s := struct {
+			A string
+			B string `order:"2"`
+			C string `order:"-1"`
+			D string
+			E string `order:"1"`
+		}{}
+
+		indices, err := sortFields("", reflect.ValueOf(s))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 0, 3, 4, 1}, indices)
+	})
+}
+
+func TestEnterSection(t *testing.T) {
+	server := &section{Title: "Server"}
+	proxy := &section{Parent: server, Title: "Proxy", Optional: true}
+
+	t.Run("AskFails", func(t *testing.T) {
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutError: errors.New("io error")}},
+			},
+		}
+
+		entered := map[*section]bool{}
+
+		ok, err := enterSection(proxy, asker, &Options{}, entered)
+		assert.EqualError(t, err, "io error")
+		assert.False(t, ok)
+		assert.Equal(t, map[*section]bool{server: true, proxy: false}, entered)
+	})
+
+	t.Run("Entered", func(t *testing.T) {
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutString: "y"}},
+			},
+		}
+
+		entered := map[*section]bool{}
+
+		for range 2 {
+			ok, err := enterSection(proxy, asker, &Options{}, entered)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}
+
+		assert.Equal(t, "  • Configure Proxy [y/N]?", asker.AskMocks[0].InPrompt)
+		assert.Equal(t, []string{"\033[1;4mServer\033[0m", "\033[1;4mProxy\033[0m"}, asker.Outputs)
+	})
+
+	t.Run("Skipped", func(t *testing.T) {
+		asker := &mockOutputAsker{
+			MockAsker: MockAsker{
+				AskMocks: []AskMock{{OutString: ""}},
+			},
+		}
+
+		entered := map[*section]bool{server: true}
+
+		for range 2 {
+			ok, err := enterSection(proxy, asker, &Options{}, entered)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		}
+
+		assert.Equal(t, []string{"\033[1;4mProxy\033[0m"}, asker.Outputs)
+	})
+}
+
+func TestAsk_Sections(t *testing.T) {
+	type config struct {
+		Name   string `ask:"any, the service name" order:"1"`
+		Server struct {
+			Host  string `ask:"hostname, the server host"`
+			Port  int    `ask:"port, the server port" default:"8080"`
+			Proxy struct {
+				URL  string `ask:"url, the proxy URL"`
+				User string `ask:"any, the proxy user" default:"admin"`
+			} `ask-section:"Proxy settings, optional"`
+		} `ask-section:"Server"`
+		Debug bool `ask:"any, enable debug logs" order:"-1"`
+	}
+
+	tests := []struct {
+		name            string
+		asker           *mockOutputAsker
+		opts            []Option
+		expectedConfig  func(*config)
+		expectedOutputs []string
+	}{
+		{
+			name: "SkipSection",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "true"},        // Debug
+						{OutString: "example.com"}, // Server.Host
+						{OutString: ""},            // Server.Port
+						{OutString: "N"},           // Proxy settings
+						{OutString: "app"},         // Name
+					},
+				},
+			},
+			opts: []Option{WithStreamlined()},
+			expectedConfig: func(c *config) {
+				c.Debug = true
+				c.Server.Host = "example.com"
+				c.Server.Port = 8080
+				c.Server.Proxy.User = "admin"
+				c.Name = "app"
+			},
+			expectedOutputs: []string{
+				"\033[1mDebug\033[0m",
+				"\033[1;4mServer\033[0m",
+				"\033[1mServer.Host\033[0m",
+				"\033[1mServer.Port\033[0m",
+				"\033[1;4mProxy settings\033[0m",
+				"\033[1mName\033[0m",
+			},
+		},
+		{
+			name: "ConfigureSection",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "false"},                    // Debug
+						{OutString: "example.com"},              // Server.Host
+						{OutString: "443"},                      // Server.Port
+						{OutString: "yes"},                      // Proxy settings
+						{OutString: "http://proxy.example.com"}, // Server.Proxy.URL
+						{OutString: "jane"},                     // Server.Proxy.User
+						{OutString: "app"},                      // Name
+					},
+				},
+			},
+			opts: []Option{WithStreamlined()},
+			expectedConfig: func(c *config) {
+				c.Server.Host = "example.com"
+				c.Server.Port = 443
+				c.Server.Proxy.URL = "http://proxy.example.com"
+				c.Server.Proxy.User = "jane"
+				c.Name = "app"
+			},
+		},
+		{
+			name: "AnsweredSection",
+			asker: &mockOutputAsker{
+				MockAsker: MockAsker{
+					AskMocks: []AskMock{
+						{OutString: "false"}, // Debug
+						{OutString: "N"},     // Proxy settings
+						{OutString: "app"},   // Name
+					},
+				},
+			},
+			opts: []Option{WithStreamlined(), WithAnswers(Answers{"Server.Host": "example.com", "Server.Port": 443})},
+			expectedConfig: func(c *config) {
+				c.Server.Host = "example.com"
+				c.Server.Port = 443
+				c.Server.Proxy.User = "admin"
+				c.Name = "app"
+			},
+			expectedOutputs: []string{
+				"\033[1mDebug\033[0m",
+				"\033[1;4mServer\033[0m",
+				"\033[1;4mProxy settings\033[0m",
+				"\033[1mName\033[0m",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := config{}
+			err := Ask(&c, tc.asker, tc.opts...)
+			assert.NoError(t, err)
+
+			expected := config{}
+			tc.expectedConfig(&expected)
+			assert.Equal(t, expected, c)
+
+			if tc.expectedOutputs != nil {
+				assert.Equal(t, tc.expectedOutputs, tc.asker.Outputs)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package askit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	optionsTag = "options"
+	optionsSep = "|"
+)
+
+// Selector is an optional interface for Askers that can present a list of options to choose from.
+// If an Asker does not implement this interface, a numbered menu is presented using the Asker methods.
+type Selector interface {
+	// Select asks for one of the options and returns the index of the selected option.
+	// A negative defaultIndex means no option is selected by default.
+	Select(prompt string, options []string, defaultIndex int) (int, error)
+	// MultiSelect asks for any number of the options and returns the indices of the selected options in order.
+	MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error)
+}
+
+// OptionsProvider is a function for providing the options of a select or multiselect field at runtime.
+type OptionsProvider func() []string
+
+var providers = struct {
+	sync.RWMutex
+	m map[string]OptionsProvider
+}{
+	m: map[string]OptionsProvider{},
+}
+
+// RegisterOptions registers a provider for the options of select and multiselect fields.
+// A field can use the registered provider by referring to its name in the options tag (options:"@name").
+func RegisterOptions(name string, provider OptionsProvider) {
+	providers.Lock()
+	defer providers.Unlock()
+
+	providers.m[name] = provider
+}
+
+// parseOptions parses the value of an options tag.
+// The options are either separated by | or provided by a registered provider (@name).
+func parseOptions(val string) []string {
+	if name, ok := strings.CutPrefix(val, "@"); ok {
+		providers.RLock()
+		provider, ok := providers.m[name]
+		providers.RUnlock()
+
+		if !ok {
+			return nil
+		}
+
+		return provider()
+	}
+
+	if val == "" {
+		return nil
+	}
+
+	options := strings.Split(val, optionsSep)
+	for i := range options {
+		options[i] = strings.TrimSpace(options[i])
+	}
+
+	return options
+}
+
+// menuSelector is implemented by Selectors that fall back to a numbered menu.
+type menuSelector interface {
+	// selectsByNumber determines whether or not the options are presented using a numbered menu.
+	selectsByNumber() bool
+}
+
+// asSelector returns the Selector for an Asker if it presents the options itself.
+// An Asker falling back to a numbered menu is not considered a Selector,
+// so the menu is presented using the same options as the rest of the prompts.
+func asSelector(asker Asker) (Selector, bool) {
+	if m, ok := asker.(menuSelector); ok && m.selectsByNumber() {
+		return nil, false
+	}
+
+	s, ok := asker.(Selector)
+	return s, ok
+}
+
+// errCleared is returned by a numbered menu if the selection is cleared.
+var errCleared = errors.New("selection cleared")
+
+// selectByNumber asks for one of the options by presenting a numbered menu.
+// If clearable is true, errCleared is returned for ClearAnswer.
+func selectByNumber(asker Asker, opts *Options, prompt string, options []string, defaultIndex int, clearable bool) (int, error) {
+	for i, opt := range options {
+		asker.Output(opts.render(MessageItem, i+1, opt))
+	}
+
+	if defaultIndex >= 0 && defaultIndex < len(options) {
+		prompt = fmt.Sprintf("%s [%d]", prompt, defaultIndex+1)
+	}
+
+	ans, err := asker.Ask(prompt)
+	if err != nil {
+		return -1, err
+	}
+
+	ans = strings.TrimSpace(ans)
+	if clearable && ans == ClearAnswer {
+		return -1, errCleared
+	}
+
+	if ans == "" {
+		if defaultIndex >= 0 && defaultIndex < len(options) {
+			return defaultIndex, nil
+		}
+		return -1, &inputError{errors.New(opts.render(MessageNoOption))}
+	}
+
+	return parseOptionNumber(opts, ans, len(options))
+}
+
+// multiSelectByNumber asks for any number of the options by presenting a numbered menu.
+// If clearable is true, errCleared is returned for ClearAnswer.
+func multiSelectByNumber(asker Asker, opts *Options, prompt string, options []string, defaultIndices []int, clearable bool) ([]int, error) {
+	for i, opt := range options {
+		asker.Output(opts.render(MessageItem, i+1, opt))
+	}
+
+	if len(defaultIndices) > 0 {
+		nums := make([]string, len(defaultIndices))
+		for i, idx := range defaultIndices {
+			nums[i] = strconv.Itoa(idx + 1)
+		}
+		prompt = fmt.Sprintf("%s [%s]", prompt, strings.Join(nums, ","))
+	}
+
+	ans, err := asker.Ask(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	ans = strings.TrimSpace(ans)
+	if clearable && ans == ClearAnswer {
+		return nil, errCleared
+	}
+
+	if ans == "" {
+		return defaultIndices, nil
+	}
+
+	indices := []int{}
+	seen := map[int]bool{}
+
+	for _, num := range strings.Split(ans, ",") {
+		i, err := parseOptionNumber(opts, strings.TrimSpace(num), len(options))
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[i] {
+			seen[i] = true
+			indices = append(indices, i)
+		}
+	}
+
+	return indices, nil
+}
+
+func parseOptionNumber(opts *Options, val string, n int) (int, error) {
+	i, err := strconv.Atoi(val)
+	if err != nil || i < 1 || i > n {
+		return -1, &inputError{
+			errors.New(opts.render(MessageInvalidOption, val, n)),
+		}
+	}
+
+	return i - 1, nil
+}
@@ -0,0 +1,208 @@
+package askit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSelector struct {
+	MockAsker
+
+	SelectOutIndex        int
+	SelectOutError        error
+	MultiSelectOutIndices []int
+	MultiSelectOutError   error
+}
+
+func (m *mockSelector) Select(string, []string, int) (int, error) {
+	return m.SelectOutIndex, m.SelectOutError
+}
+
+func (m *mockSelector) MultiSelect(string, []string, []int) ([]int, error) {
+	return m.MultiSelectOutIndices, m.MultiSelectOutError
+}
+
+func TestParseOptions(t *testing.T) {
+	RegisterOptions("envs", func() []string {
+		return []string{"dev", "prod"}
+	})
+
+	tests := []struct {
+		name            string
+		val             string
+		expectedOptions []string
+	}{
+		{"Empty", "", nil},
+		{"Single", "dev", []string{"dev"}},
+		{"Multiple", "dev | staging|prod", []string{"dev", "staging", "prod"}},
+		{"Provider", "@envs", []string{"dev", "prod"}},
+		{"UnknownProvider", "@unknown", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedOptions, parseOptions(tc.val))
+		})
+	}
+}
+
+func TestAsSelector(t *testing.T) {
+	tests := []struct {
+		name       string
+		asker      Asker
+		expectedOK bool
+	}{
+		{"Asker", &MockAsker{}, false},
+		{"Selector", &mockSelector{}, true},
+		{"Terminal", NewTerminal(strings.NewReader(""), io.Discard), false},
+		{"Recorder", NewRecorder(&MockAsker{}, io.Discard), false},
+		{"RecorderSelector", NewRecorder(&mockSelector{}, io.Discard), true},
+		{"ContextAsker", newContextAsker(context.Background(), &mockSelector{}, 0), true},
+		{"ContextAskerTimeout", newContextAsker(context.Background(), &mockSelector{}, time.Second), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := asSelector(tc.asker)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestSelectByNumber(t *testing.T) {
+	options := []string{"dev", "staging", "prod"}
+
+	tests := []struct {
+		name           string
+		asker          *MockAsker
+		defaultIndex   int
+		expectedPrompt string
+		expectedIndex  int
+		expectedError  string
+	}{
+		{
+			name:           "AskFails",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutError: errors.New("io error")}}},
+			defaultIndex:   -1,
+			expectedPrompt: "Select:",
+			expectedError:  "io error",
+		},
+		{
+			name:           "NoDefault",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			defaultIndex:   -1,
+			expectedPrompt: "Select:",
+			expectedError:  "no option selected",
+		},
+		{
+			name:           "Default",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			defaultIndex:   1,
+			expectedPrompt: "Select: [2]",
+			expectedIndex:  1,
+		},
+		{
+			name:           "Invalid",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: "4"}}},
+			defaultIndex:   -1,
+			expectedPrompt: "Select:",
+			expectedError:  "invalid option: 4 (enter a number between 1 and 3)",
+		},
+		{
+			name:           "Success",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: " 3 "}}},
+			defaultIndex:   0,
+			expectedPrompt: "Select: [1]",
+			expectedIndex:  2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := selectByNumber(tc.asker, nil, "Select:", options, tc.defaultIndex, false)
+			assert.Equal(t, tc.expectedPrompt, tc.asker.AskMocks[0].InPrompt)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIndex, i)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMultiSelectByNumber(t *testing.T) {
+	options := []string{"dev", "staging", "prod"}
+
+	tests := []struct {
+		name            string
+		asker           *MockAsker
+		defaultIndices  []int
+		expectedPrompt  string
+		expectedIndices []int
+		expectedError   string
+	}{
+		{
+			name:           "AskFails",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutError: errors.New("io error")}}},
+			expectedPrompt: "Select:",
+			expectedError:  "io error",
+		},
+		{
+			name:            "Default",
+			asker:           &MockAsker{AskMocks: []AskMock{{OutString: ""}}},
+			defaultIndices:  []int{0, 2},
+			expectedPrompt:  "Select: [1,3]",
+			expectedIndices: []int{0, 2},
+		},
+		{
+			name:           "Invalid",
+			asker:          &MockAsker{AskMocks: []AskMock{{OutString: "1,x"}}},
+			expectedPrompt: "Select:",
+			expectedError:  "invalid option: x (enter a number between 1 and 3)",
+		},
+		{
+			name:            "Success",
+			asker:           &MockAsker{AskMocks: []AskMock{{OutString: "3, 1, 3"}}},
+			expectedPrompt:  "Select:",
+			expectedIndices: []int{2, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			indices, err := multiSelectByNumber(tc.asker, nil, "Select:", options, tc.defaultIndices, false)
+			assert.Equal(t, tc.expectedPrompt, tc.asker.AskMocks[0].InPrompt)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIndices, indices)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestSelectByNumber_Clear(t *testing.T) {
+	options := []string{"dev", "staging", "prod"}
+
+	asker := &MockAsker{AskMocks: []AskMock{{OutString: ":clear"}, {OutString: ":clear"}}}
+	_, err := selectByNumber(asker, nil, "Select:", options, 1, true)
+	assert.Equal(t, errCleared, err)
+	_, err = selectByNumber(asker, nil, "Select:", options, 1, false)
+	assert.EqualError(t, err, "invalid option: :clear (enter a number between 1 and 3)")
+
+	asker = &MockAsker{AskMocks: []AskMock{{OutString: ":clear"}, {OutString: ":clear"}}}
+	_, err = multiSelectByNumber(asker, nil, "Select:", options, []int{0}, true)
+	assert.Equal(t, errCleared, err)
+	_, err = multiSelectByNumber(asker, nil, "Select:", options, []int{0}, false)
+	assert.EqualError(t, err, "invalid option: :clear (enter a number between 1 and 3)")
+}
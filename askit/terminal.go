@@ -0,0 +1,536 @@
+package askit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"unicode"
+)
+
+// Terminal is an Asker for reading inputs from a reader and writing outputs to a writer.
+// If the reader is a terminal (or a Windows console), secrets are read with echo disabled.
+// Otherwise, secrets are read the same as other inputs.
+// On platforms without terminal control (e.g. js and plan9), secrets are echoed.
+type Terminal struct {
+	in   *bufio.Reader
+	out  io.Writer
+	fd   uintptr
+	tty  bool
+	file *os.File
+	opts *Options
+
+	// pending is the result of a read abandoned due to cancellation.
+	// The line read is discarded, since it belongs to another prompt.
+	pending chan lineResult
+	// pendingSecret determines whether the pending read is for a secret, in which case echo stays disabled until it returns.
+	pendingSecret bool
+}
+
+type lineResult struct {
+	line string
+	err  error
+}
+
+// NewTerminal creates a new Asker for reading inputs from in and writing outputs to out.
+// Usually, in and out are os.Stdin and os.Stdout respectively.
+// The renderer from the options (see WithRenderer) is used for the texts shown by the Terminal itself.
+func NewTerminal(in io.Reader, out io.Writer, opts ...Option) *Terminal {
+	t := &Terminal{
+		in:   bufio.NewReader(in),
+		out:  out,
+		opts: newOptions(opts...),
+	}
+
+	if f, ok := in.(interface{ Fd() uintptr }); ok && isTerminal(f.Fd()) {
+		t.fd = f.Fd()
+		t.tty = true
+		t.file, _ = in.(*os.File)
+	}
+
+	return t
+}
+
+// Output writes a message to the output.
+func (t *Terminal) Output(message string) {
+	_, _ = fmt.Fprintln(t.out, message)
+}
+
+// Ask writes a prompt to the output and reads a line from the input.
+func (t *Terminal) Ask(prompt string) (string, error) {
+	return t.AskContext(context.Background(), prompt)
+}
+
+// AskContext writes a prompt to the output and reads a line from the input until the context is cancelled.
+// If the context is cancelled, the line is still read from the input, but it is discarded.
+func (t *Terminal) AskContext(ctx context.Context, prompt string) (string, error) {
+	_, _ = fmt.Fprint(t.out, prompt+" ")
+	return t.readLineContext(ctx, prompt, false, nil)
+}
+
+// AskSecret writes a prompt to the output and reads a line from the input without echoing it.
+// The terminal state is restored even if the process receives an interrupt or termination signal.
+func (t *Terminal) AskSecret(prompt string) (string, error) {
+	return t.AskSecretContext(context.Background(), prompt)
+}
+
+// AskSecretContext writes a prompt to the output and reads a line from the input without echoing it until the context is cancelled.
+// If the context is cancelled, the line is still read from the input without echoing it, but it is discarded.
+// The terminal state is restored even if the process receives an interrupt or termination signal.
+func (t *Terminal) AskSecretContext(ctx context.Context, prompt string) (string, error) {
+	_, _ = fmt.Fprint(t.out, prompt+" ")
+
+	if !t.tty {
+		return t.readLineContext(ctx, prompt, false, nil)
+	}
+
+	// Echo is already disabled for a pending secret read, so it is restored before disabling it again
+	if t.pendingSecret {
+		if err := t.drain(ctx, prompt, true); err != nil {
+			return "", err
+		}
+	}
+
+	restore, err := disableEcho(t.fd)
+	if err != nil {
+		return t.readLineContext(ctx, prompt, false, nil)
+	}
+
+	stop := restoreOnSignal(restore)
+	defer func() {
+		// The new line entered by the user is not echoed
+		_, _ = fmt.Fprintln(t.out)
+	}()
+
+	// If the read is abandoned, echo stays disabled until it returns
+	return t.readLineContext(ctx, prompt, true, func() {
+		stop()
+		_ = restore()
+	})
+}
+
+// AskPath writes a prompt to the output and reads a path from the input.
+// If the input is a terminal, the tab key completes the path against the local filesystem.
+// If there are multiple matches, pressing the tab key again lists them.
+// Otherwise, the path is read the same as other inputs.
+func (t *Terminal) AskPath(prompt string) (string, error) {
+	// A pending read cannot be shared with the line editor
+	if !t.tty || t.pending != nil {
+		return t.Ask(prompt)
+	}
+
+	restore, err := makeRaw(t.fd)
+	if err != nil {
+		return t.Ask(prompt)
+	}
+
+	stop := restoreOnSignal(restore)
+	defer func() {
+		stop()
+		_ = restore()
+	}()
+
+	_, _ = fmt.Fprint(t.out, prompt+" ")
+
+	line := []rune{}
+	for {
+		r, _, err := t.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			_, _ = fmt.Fprint(t.out, "\r\n")
+			return string(line), nil
+
+		case '\t':
+			completed, matches := completePath(string(line))
+			if completed != string(line) {
+				_, _ = fmt.Fprint(t.out, strings.TrimPrefix(completed, string(line)))
+				line = []rune(completed)
+			} else if len(matches) > 1 {
+				_, _ = fmt.Fprintf(t.out, "\r\n%s\r\n%s %s", strings.Join(matches, "  "), prompt, string(line))
+			} else {
+				_, _ = fmt.Fprint(t.out, "\a")
+			}
+
+		case 0x7f, 0x08: // Backspace
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				_, _ = fmt.Fprint(t.out, "\b \b")
+			}
+
+		case 0x04: // Ctrl+D
+			if len(line) == 0 {
+				_, _ = fmt.Fprint(t.out, "\r\n")
+				return "", io.EOF
+			}
+
+		case 0x1b: // Escape sequences are ignored
+			if b, err := t.in.ReadByte(); err != nil || b != '[' {
+				continue
+			}
+			for {
+				if b, err := t.in.ReadByte(); err != nil || (b >= 0x40 && b <= 0x7e) {
+					break
+				}
+			}
+
+		default:
+			if unicode.IsPrint(r) {
+				line = append(line, r)
+				_, _ = fmt.Fprint(t.out, string(r))
+			}
+		}
+	}
+}
+
+// Edit opens the editor set by $VISUAL or $EDITOR on a temporary file pre-filled with a text.
+// Once the editor exits, the edited text is read back from the file.
+// If the input is not a terminal or no editor is set, ErrNoEditor is returned.
+func (t *Terminal) Edit(text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	// A pending read cannot be shared with the editor
+	args := strings.Fields(editor)
+	if len(args) == 0 || t.file == nil || t.pending != nil {
+		return "", ErrNoEditor
+	}
+
+	f, err := os.CreateTemp("", "askit-*.txt")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	out, closeOut := t.editorOutput()
+	defer closeOut()
+
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	cmd.Stdin = t.file
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor failed: %s", err)
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// editorOutput returns the output for an editor.
+// The input file may be read-only, so the output is used if it is a file.
+// Otherwise, the controlling terminal is opened, or the output is used as is.
+func (t *Terminal) editorOutput() (io.Writer, func()) {
+	if f, ok := t.out.(*os.File); ok {
+		return f, func() {}
+	}
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		return tty, func() { _ = tty.Close() }
+	}
+
+	return t.out, func() {}
+}
+
+// readLineContext reads a line from the input for a prompt until the context is cancelled.
+// A read abandoned due to cancellation keeps reading from the input, so it is waited for first and its line is discarded.
+// If after is not nil, it is called once the read returns, even if the read is abandoned.
+func (t *Terminal) readLineContext(ctx context.Context, prompt string, secret bool, after func()) (string, error) {
+	if after == nil {
+		after = func() {}
+	}
+
+	if err := t.drain(ctx, prompt, secret); err != nil {
+		after()
+		return "", err
+	}
+
+	if ctx.Done() == nil {
+		defer after()
+		return t.readLine()
+	}
+
+	ch := make(chan lineResult, 1)
+	go func() {
+		line, err := t.readLine()
+		after()
+		ch <- lineResult{line, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.line, r.err
+	case <-ctx.Done():
+		t.pending, t.pendingSecret = ch, secret
+		return "", ctx.Err()
+	}
+}
+
+// drain waits for a pending read until the context is cancelled and discards its line.
+// If the line is entered after the prompt is shown, the prompt is shown again.
+func (t *Terminal) drain(ctx context.Context, prompt string, secret bool) error {
+	if t.pending == nil {
+		return nil
+	}
+
+	select {
+	case <-t.pending:
+		t.pending, t.pendingSecret = nil, false
+		return nil
+	default:
+	}
+
+	select {
+	case <-t.pending:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// The new line entered by the user is not echoed
+	if t.tty && (secret || t.pendingSecret) {
+		_, _ = fmt.Fprintln(t.out)
+	}
+
+	t.pending, t.pendingSecret = nil, false
+	_, _ = fmt.Fprint(t.out, prompt+" ")
+
+	return nil
+}
+
+func (t *Terminal) readLine() (string, error) {
+	line, err := t.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// restoreOnSignal restores the terminal state if the process receives an interrupt or termination signal.
+// After restoring the terminal, the signal is raised again so the application can handle it as usual.
+// The returned function stops watching for signals.
+func restoreOnSignal(restore func() error) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-sigCh:
+			_ = restore()
+			signal.Stop(sigCh)
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				_ = p.Signal(sig)
+			}
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+type key int
+
+const (
+	keyOther key = iota
+	keyUp
+	keyDown
+	keySpace
+	keyEnter
+	keyEOF
+)
+
+// readKey reads a key press from the input in raw mode.
+func (t *Terminal) readKey() (key, error) {
+	b, err := t.in.ReadByte()
+	if err != nil {
+		return keyOther, err
+	}
+
+	switch b {
+	case '\r', '\n':
+		return keyEnter, nil
+	case ' ':
+		return keySpace, nil
+	case 'k':
+		return keyUp, nil
+	case 'j':
+		return keyDown, nil
+	case 0x04: // Ctrl+D
+		return keyEOF, nil
+	case 0x1b: // Escape sequence
+		if b, err = t.in.ReadByte(); err != nil || b != '[' {
+			return keyOther, err
+		}
+
+		if b, err = t.in.ReadByte(); err != nil {
+			return keyOther, err
+		}
+
+		switch b {
+		case 'A':
+			return keyUp, nil
+		case 'B':
+			return keyDown, nil
+		}
+	}
+
+	return keyOther, nil
+}
+
+// renderList writes a list of options with the current option marked.
+// If checked is not nil, the options are rendered with checkboxes.
+// If redraw is true, the previously rendered list is overwritten.
+func (t *Terminal) renderList(options []string, cursor int, checked []bool, redraw bool) {
+	if redraw {
+		_, _ = fmt.Fprintf(t.out, "\033[%dA", len(options))
+	}
+
+	for i, opt := range options {
+		marker := " "
+		if i == cursor {
+			marker = ">"
+		}
+
+		if checked != nil {
+			box := "[ ]"
+			if checked[i] {
+				box = "[x]"
+			}
+			opt = box + " " + opt
+		}
+
+		_, _ = fmt.Fprintf(t.out, "\r\033[2K    %s %s\r\n", marker, opt)
+	}
+}
+
+// errNoRawMode is returned by chooseFromList if the terminal cannot be put into raw mode.
+var errNoRawMode = errors.New("raw mode is not supported by the terminal")
+
+// chooseFromList presents an arrow-key navigable list of options in raw mode.
+// If checked is not nil, the space key toggles the current option.
+// It returns the index of the current option when the enter key is pressed.
+func (t *Terminal) chooseFromList(prompt, hint string, options []string, cursor int, checked []bool) (int, error) {
+	restore, err := makeRaw(t.fd)
+	if err != nil {
+		return -1, errNoRawMode
+	}
+
+	stop := restoreOnSignal(restore)
+	defer func() {
+		stop()
+		_ = restore()
+	}()
+
+	_, _ = fmt.Fprintf(t.out, "%s (%s)\r\n", prompt, hint)
+	t.renderList(options, cursor, checked, false)
+
+	for {
+		k, err := t.readKey()
+		if err != nil {
+			return -1, err
+		}
+
+		switch k {
+		case keyUp:
+			cursor = (cursor - 1 + len(options)) % len(options)
+		case keyDown:
+			cursor = (cursor + 1) % len(options)
+		case keySpace:
+			if checked != nil {
+				checked[cursor] = !checked[cursor]
+			}
+		case keyEnter:
+			return cursor, nil
+		case keyEOF:
+			return -1, io.EOF
+		}
+
+		t.renderList(options, cursor, checked, true)
+	}
+}
+
+// selectsByNumber determines whether or not a numbered menu is presented instead of an arrow-key navigable list.
+func (t *Terminal) selectsByNumber() bool {
+	// A pending read cannot be shared with the list
+	return !t.tty || t.pending != nil
+}
+
+// Select asks for one of the options and returns the index of the selected option.
+// If the input is a terminal, an arrow-key navigable list is presented.
+// Otherwise, a numbered menu is presented.
+func (t *Terminal) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	if t.selectsByNumber() || len(options) == 0 {
+		return selectByNumber(t, t.opts, prompt, options, defaultIndex, false)
+	}
+
+	i, err := t.chooseFromList(prompt, t.opts.render(MessageSelectHelp), options, max(defaultIndex, 0), nil)
+	if errors.Is(err, errNoRawMode) {
+		return selectByNumber(t, t.opts, prompt, options, defaultIndex, false)
+	}
+
+	return i, err
+}
+
+// MultiSelect asks for any number of the options and returns the indices of the selected options in order.
+// If the input is a terminal, an arrow-key navigable list with checkboxes is presented.
+// Otherwise, a numbered menu is presented.
+func (t *Terminal) MultiSelect(prompt string, options []string, defaultIndices []int) ([]int, error) {
+	if t.selectsByNumber() || len(options) == 0 {
+		return multiSelectByNumber(t, t.opts, prompt, options, defaultIndices, false)
+	}
+
+	checked := make([]bool, len(options))
+	for _, i := range defaultIndices {
+		if i >= 0 && i < len(options) {
+			checked[i] = true
+		}
+	}
+
+	if _, err := t.chooseFromList(prompt, t.opts.render(MessageMultiSelectHelp), options, 0, checked); errors.Is(err, errNoRawMode) {
+		return multiSelectByNumber(t, t.opts, prompt, options, defaultIndices, false)
+	} else if err != nil {
+		return nil, err
+	}
+
+	indices := []int{}
+	for i, c := range checked {
+		if c {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices, nil
+}
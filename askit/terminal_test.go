@@ -0,0 +1,239 @@
+package askit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errorReader struct{}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("io error")
+}
+
+func TestNewTerminal(t *testing.T) {
+	term := NewTerminal(strings.NewReader(""), io.Discard)
+
+	assert.NotNil(t, term)
+	assert.NotNil(t, term.in)
+	assert.NotNil(t, term.out)
+	assert.False(t, term.tty)
+}
+
+func TestTerminal_Output(t *testing.T) {
+	out := new(bytes.Buffer)
+	term := NewTerminal(strings.NewReader(""), out)
+
+	term.Output("Hello, World!")
+	assert.Equal(t, "Hello, World!\n", out.String())
+}
+
+func TestTerminal_Ask(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             io.Reader
+		prompt         string
+		expectedOutput string
+		expectedValue  string
+		expectedError  string
+	}{
+		{
+			name:           "ReadFails",
+			in:             new(errorReader),
+			prompt:         "Enter a value:",
+			expectedOutput: "Enter a value: ",
+			expectedError:  "io error",
+		},
+		{
+			name:           "EOF",
+			in:             strings.NewReader(""),
+			prompt:         "Enter a value:",
+			expectedOutput: "Enter a value: ",
+			expectedError:  "EOF",
+		},
+		{
+			name:           "EOFAfterValue",
+			in:             strings.NewReader("value"),
+			prompt:         "Enter a value:",
+			expectedOutput: "Enter a value: ",
+			expectedValue:  "value",
+		},
+		{
+			name:           "Success",
+			in:             strings.NewReader("value\r\nnext\n"),
+			prompt:         "Enter a value:",
+			expectedOutput: "Enter a value: ",
+			expectedValue:  "value",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			term := NewTerminal(tc.in, out)
+
+			val, err := term.Ask(tc.prompt)
+			assert.Equal(t, tc.expectedOutput, out.String())
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedValue, val)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestTerminal_AskSecret(t *testing.T) {
+	out := new(bytes.Buffer)
+	term := NewTerminal(strings.NewReader("secret\nnext\n"), out)
+
+	val, err := term.AskSecret("Enter a secret:")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", val)
+	assert.Equal(t, "Enter a secret: ", out.String())
+
+	val, err = term.Ask("Enter a value:")
+	assert.NoError(t, err)
+	assert.Equal(t, "next", val)
+}
+
+func TestTerminal_AskContext(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	out := new(bytes.Buffer)
+	term := NewTerminal(r, out)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := term.AskContext(ctx, "Enter a value:")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The line read by the abandoned read is discarded
+	_, err = w.Write([]byte("late\n"))
+	assert.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	go func() {
+		_, _ = w.Write([]byte("secret\n"))
+	}()
+
+	val, err := term.AskSecretContext(context.Background(), "Enter a secret:")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", val)
+	assert.Equal(t, "Enter a value: Enter a secret: ", out.String())
+
+	// The prompt is shown again if the line is entered for the next prompt
+	out.Reset()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = term.AskContext(ctx, "Enter a value:")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte("late\n"))
+		_, _ = w.Write([]byte("name\n"))
+	}()
+
+	val, err = term.Ask("Enter a name:")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", val)
+	assert.Equal(t, "Enter a value: Enter a name: Enter a name: ", out.String())
+
+	// Waiting for the abandoned read can time out too
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = term.AskContext(ctx, "Enter a value:")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = term.AskSecretContext(ctx, "Enter a secret:")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestTerminal_Select(t *testing.T) {
+	out := new(bytes.Buffer)
+	term := NewTerminal(strings.NewReader("2\n"), out)
+
+	i, err := term.Select("Select:", []string{"dev", "prod"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	assert.Equal(t, "      1) dev\n      2) prod\nSelect: [1] ", out.String())
+}
+
+func TestTerminal_MultiSelect(t *testing.T) {
+	out := new(bytes.Buffer)
+	term := NewTerminal(strings.NewReader("2,1\n"), out)
+
+	indices, err := term.MultiSelect("Select:", []string{"dev", "prod"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, indices)
+	assert.Equal(t, "      1) dev\n      2) prod\nSelect: ", out.String())
+}
+
+func TestTerminal_AskPath(t *testing.T) {
+	out := new(bytes.Buffer)
+	term := NewTerminal(strings.NewReader("~/config.yaml\n"), out)
+
+	// Paths are not completed when the input is not a terminal
+	val, err := term.AskPath("Enter a path:")
+	assert.NoError(t, err)
+	assert.Equal(t, "~/config.yaml", val)
+	assert.Equal(t, "Enter a path: ", out.String())
+}
+
+func TestTerminal_Edit(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "vi")
+
+	// Editors are not run when the input is not a terminal
+	term := NewTerminal(strings.NewReader(""), new(bytes.Buffer))
+
+	text, err := term.Edit("text")
+	assert.Equal(t, ErrNoEditor, err)
+	assert.Empty(t, text)
+}
+
+func TestTerminal_ReadKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		expectedKey key
+	}{
+		{"Enter", "\r", keyEnter},
+		{"NewLine", "\n", keyEnter},
+		{"Space", " ", keySpace},
+		{"K", "k", keyUp},
+		{"J", "j", keyDown},
+		{"Up", "\x1b[A", keyUp},
+		{"Down", "\x1b[B", keyDown},
+		{"Right", "\x1b[C", keyOther},
+		{"Escape", "\x1bx", keyOther},
+		{"EOF", "\x04", keyEOF},
+		{"Other", "x", keyOther},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			term := NewTerminal(strings.NewReader(tc.in), io.Discard)
+			k, err := term.readKey()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedKey, k)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package askit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// openPTY opens a pseudo-terminal pair for testing terminal control.
+func openPTY(t *testing.T) (*os.File, *os.File) {
+	ptm, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals not available: %s", err)
+	}
+
+	var unlock int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptm.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		_ = ptm.Close()
+		t.Skipf("cannot unlock pseudo-terminal: %s", errno)
+	}
+
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptm.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		_ = ptm.Close()
+		t.Skipf("cannot get pseudo-terminal number: %s", errno)
+	}
+
+	pts, err := os.OpenFile("/dev/pts/"+strconv.FormatUint(uint64(n), 10), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		_ = ptm.Close()
+		t.Skipf("cannot open pseudo-terminal: %s", err)
+	}
+
+	t.Cleanup(func() {
+		_ = pts.Close()
+		_ = ptm.Close()
+	})
+
+	return ptm, pts
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.False(t, isTerminal(f.Fd()))
+
+	_, pts := openPTY(t)
+	assert.True(t, isTerminal(pts.Fd()))
+}
+
+func TestDisableEcho(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	_, err = disableEcho(f.Fd())
+	assert.Error(t, err)
+
+	_, pts := openPTY(t)
+
+	old, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+
+	restore, err := disableEcho(pts.Fd())
+	assert.NoError(t, err)
+
+	state, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.Zero(t, state.Lflag&syscall.ECHO)
+
+	assert.NoError(t, restore())
+
+	state, err = getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.Equal(t, old.Lflag, state.Lflag)
+}
+
+func TestTerminal_AskSecret_TTY(t *testing.T) {
+	ptm, pts := openPTY(t)
+
+	term := NewTerminal(pts, pts)
+	assert.True(t, term.tty)
+
+	_, err := ptm.Write([]byte("secret\n"))
+	assert.NoError(t, err)
+
+	val, err := term.AskSecret("Enter a secret:")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", val)
+
+	state, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.NotZero(t, state.Lflag&syscall.ECHO)
+}
+
+func TestTerminal_AskSecretContext_TTY(t *testing.T) {
+	ptm, pts := openPTY(t)
+
+	out := new(bytes.Buffer)
+	term := NewTerminal(pts, out)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := term.AskSecretContext(ctx, "Enter a secret:")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Echo stays disabled for the abandoned read
+	state, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.Zero(t, state.Lflag&syscall.ECHO)
+
+	ch := make(chan string, 1)
+	go func() {
+		val, _ := term.Ask("Enter a name:")
+		ch <- val
+	}()
+
+	// The late secret is discarded
+	time.Sleep(10 * time.Millisecond)
+	_, err = ptm.Write([]byte("s3cr3t\n"))
+	assert.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = ptm.Write([]byte("jane\n"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "jane", <-ch)
+	assert.Equal(t, "Enter a secret: \nEnter a name: \nEnter a name: ", out.String())
+
+	state, err = getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.NotZero(t, state.Lflag&syscall.ECHO)
+
+	// Only the name is echoed
+	buf := make([]byte, 1024)
+	n, err := ptm.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane\r\n", string(buf[:n]))
+}
+
+func TestMakeRaw(t *testing.T) {
+	_, pts := openPTY(t)
+
+	restore, err := makeRaw(pts.Fd())
+	assert.NoError(t, err)
+
+	state, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.Zero(t, state.Lflag&(syscall.ECHO|syscall.ICANON))
+	assert.NotZero(t, state.Lflag&syscall.ISIG)
+
+	assert.NoError(t, restore())
+
+	state, err = getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.NotZero(t, state.Lflag&syscall.ICANON)
+}
+
+func TestTerminal_Select_TTY(t *testing.T) {
+	ptm, pts := openPTY(t)
+	term := NewTerminal(pts, pts)
+
+	// Move down twice, up once, and select
+	_, err := ptm.Write([]byte("\x1b[B\x1b[Bk\r"))
+	assert.NoError(t, err)
+
+	i, err := term.Select("Select:", []string{"dev", "staging", "prod"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+}
+
+func TestTerminal_Select_TTY_Renderer(t *testing.T) {
+	ptm, pts := openPTY(t)
+	out := new(bytes.Buffer)
+	term := NewTerminal(pts, out, WithRenderer(NewRenderer(Catalog{
+		MessageSelectHelp:      "↑/↓ bewegen, Enter auswählen",
+		MessageMultiSelectHelp: "↑/↓ bewegen, Leertaste umschalten, Enter bestätigen",
+	}, Styles{})))
+
+	_, err := ptm.Write([]byte("\r \r"))
+	assert.NoError(t, err)
+
+	i, err := term.Select("Select:", []string{"dev", "prod"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	indices, err := term.MultiSelect("Select:", []string{"dev", "prod"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, indices)
+
+	assert.Contains(t, out.String(), "Select: (↑/↓ bewegen, Enter auswählen)\r\n")
+	assert.Contains(t, out.String(), "Select: (↑/↓ bewegen, Leertaste umschalten, Enter bestätigen)\r\n")
+}
+
+func TestTerminal_MultiSelect_TTY(t *testing.T) {
+	ptm, pts := openPTY(t)
+	term := NewTerminal(pts, pts)
+
+	// Uncheck the first option, check the third option, and confirm
+	_, err := ptm.Write([]byte(" jj \r"))
+	assert.NoError(t, err)
+
+	indices, err := term.MultiSelect("Select:", []string{"dev", "staging", "prod"}, []int{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, indices)
+}
+
+func TestTerminal_Edit_TTY(t *testing.T) {
+	_, pts := openPTY(t)
+	term := NewTerminal(pts, pts)
+
+	t.Run("NoEditor", func(t *testing.T) {
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "")
+
+		_, err := term.Edit("text")
+		assert.Equal(t, ErrNoEditor, err)
+	})
+
+	t.Run("EditorFails", func(t *testing.T) {
+		t.Setenv("VISUAL", "false")
+
+		_, err := term.Edit("text")
+		assert.EqualError(t, err, "editor failed: exit status 1")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "sed -i s/old/new/")
+
+		text, err := term.Edit("# header\nold value\n")
+		assert.NoError(t, err)
+		assert.Equal(t, "# header\nnew value\n", text)
+	})
+
+	t.Run("ReadOnlyInput", func(t *testing.T) {
+		in, err := os.OpenFile(pts.Name(), os.O_RDONLY|syscall.O_NOCTTY, 0)
+		assert.NoError(t, err)
+		defer in.Close()
+
+		script := filepath.Join(t.TempDir(), "editor.sh")
+		assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho editing || exit 1\nsed -i s/old/new/ \"$1\"\n"), 0o755))
+		t.Setenv("VISUAL", script)
+
+		term := NewTerminal(in, pts)
+		text, err := term.Edit("old value")
+		assert.NoError(t, err)
+		assert.Equal(t, "new value", text)
+	})
+}
+
+func TestTerminal_AskPath_TTY(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "certs.pem"), nil, 0o644))
+
+	ptm, pts := openPTY(t)
+	term := NewTerminal(pts, pts)
+
+	// Complete the path, ignore an arrow key, and erase a character
+	_, err := ptm.Write([]byte(dir + "/ce\t\x1b[Ax\x7f\r"))
+	assert.NoError(t, err)
+
+	val, err := term.AskPath("Enter a path:")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "certs.pem"), val)
+
+	state, err := getTermios(pts.Fd())
+	assert.NoError(t, err)
+	assert.NotZero(t, state.Lflag&syscall.ICANON)
+}
@@ -0,0 +1,22 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd || windows)
+
+package askit
+
+import "errors"
+
+var errNoTerminal = errors.New("terminal control is not supported on this platform")
+
+// isTerminal determines whether a file descriptor refers to a terminal.
+func isTerminal(uintptr) bool {
+	return false
+}
+
+// disableEcho turns off echoing input characters on a terminal.
+func disableEcho(uintptr) (func() error, error) {
+	return nil, errNoTerminal
+}
+
+// makeRaw puts a terminal into raw mode.
+func makeRaw(uintptr) (func() error, error) {
+	return nil, errNoTerminal
+}
@@ -0,0 +1,79 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package askit
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	t := new(syscall.Termios)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlGetTermios, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return nil, errno
+	}
+
+	return t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// isTerminal determines whether a file descriptor refers to a terminal.
+func isTerminal(fd uintptr) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+// disableEcho turns off echoing input characters on a terminal.
+// Line editing and signal generation are left intact.
+// The returned function restores the terminal to its previous state.
+func disableEcho(fd uintptr) (func() error, error) {
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *old
+	t.Lflag &^= syscall.ECHO
+	t.Lflag |= syscall.ICANON | syscall.ISIG
+	t.Iflag |= syscall.ICRNL
+
+	if err := setTermios(fd, &t); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return setTermios(fd, old)
+	}, nil
+}
+
+// makeRaw puts a terminal into raw mode for reading input byte by byte without echo.
+// Signal generation is left intact, so the terminal can be restored on interrupts.
+// The returned function restores the terminal to its previous state.
+func makeRaw(fd uintptr) (func() error, error) {
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *old
+	t.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.IEXTEN
+	t.Lflag |= syscall.ISIG
+	t.Iflag &^= syscall.IXON | syscall.ICRNL
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+
+	if err := setTermios(fd, &t); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return setTermios(fd, old)
+	}, nil
+}
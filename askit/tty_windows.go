@@ -0,0 +1,74 @@
+package askit
+
+import "syscall"
+
+const (
+	enableProcessedInput       = 0x0001
+	enableLineInput            = 0x0002
+	enableEchoInput            = 0x0004
+	enableVirtualTerminalInput = 0x0200
+)
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+func getConsoleMode(fd uintptr) (uint32, error) {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(fd), &mode); err != nil {
+		return 0, err
+	}
+
+	return mode, nil
+}
+
+func setConsoleMode(fd uintptr, mode uint32) error {
+	if r, _, err := procSetConsoleMode.Call(fd, uintptr(mode)); r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// isTerminal determines whether a file descriptor refers to a console.
+func isTerminal(fd uintptr) bool {
+	_, err := getConsoleMode(fd)
+	return err == nil
+}
+
+// disableEcho turns off echoing input characters on a console.
+// Line editing and Ctrl+C handling are left intact.
+// The returned function restores the console to its previous state.
+func disableEcho(fd uintptr) (func() error, error) {
+	old, err := getConsoleMode(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := old&^enableEchoInput | enableLineInput | enableProcessedInput
+	if err := setConsoleMode(fd, mode); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return setConsoleMode(fd, old)
+	}, nil
+}
+
+// makeRaw puts a console into raw mode for reading input byte by byte without echo.
+// Keys are reported as virtual terminal sequences, which requires Windows 10 or later.
+// Ctrl+C handling is left intact, so the console can be restored on interrupts.
+// The returned function restores the console to its previous state.
+func makeRaw(fd uintptr) (func() error, error) {
+	old, err := getConsoleMode(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := old&^(enableEchoInput|enableLineInput) | enableProcessedInput | enableVirtualTerminalInput
+	if err := setConsoleMode(fd, mode); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return setConsoleMode(fd, old)
+	}, nil
+}
@@ -0,0 +1,487 @@
+package askit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardenbed/charm/internal/rflct"
+)
+
+const sessionCookie = "askit_session"
+
+var formTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; }
+label { display: block; margin-top: 1em; font-weight: bold; }
+small { display: block; color: #666; }
+input[type=text], input[type=password], select, textarea { width: 100%; box-sizing: border-box; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Saved}}<p>The changes are saved. You can close this page.</p>{{else}}
+{{if .Invalid}}<p class="error">Some values are invalid.</p>{{end}}
+<form method="post">
+<input type="hidden" name="csrf" value="{{.CSRF}}">
+{{range .Fields}}{{if .Section}}<h2>{{.Section}}</h2>{{end}}
+<label for="{{.Name}}">{{.Name}}{{if .Required}} *{{end}}</label>
+{{if .Description}}<small>{{.Description}}</small>{{end}}
+{{if .Options}}<select id="{{.Name}}" name="{{.Name}}"{{if .Multiple}} multiple{{end}}>
+{{range .Options}}<option value="{{.Value}}"{{if .Selected}} selected{{end}}>{{.Value}}</option>
+{{end}}</select>
+{{else if .Checkbox}}<input type="checkbox" id="{{.Name}}" name="{{.Name}}" value="true"{{if eq .Value "true"}} checked{{end}}>
+{{else if .Textarea}}<textarea id="{{.Name}}" name="{{.Name}}" rows="5">{{.Value}}</textarea>
+{{else if .Secret}}<input type="password" id="{{.Name}}" name="{{.Name}}" autocomplete="off"{{if .HasValue}} placeholder="unchanged"{{end}}>
+{{else}}<input type="text" id="{{.Name}}" name="{{.Name}}" value="{{.Value}}"{{if .Default}} placeholder="{{.Default}}"{{end}}>
+{{end}}{{if .Error}}<small class="error">{{.Error}}</small>{{end}}
+{{end}}
+<p><button type="submit">Save</button></p>
+</form>{{end}}
+</body>
+</html>
+`))
+
+// formData is the data for rendering the form.
+type formData struct {
+	Title   string
+	CSRF    string
+	Saved   bool
+	Invalid bool
+	Fields  []formField
+}
+
+// formField is a field rendered as an input in the form.
+type formField struct {
+	Name        string
+	Description string
+	Section     string
+	Value       string
+	Default     string
+	Options     []formOption
+	Multiple    bool
+	Checkbox    bool
+	Textarea    bool
+	Secret      bool
+	HasValue    bool
+	Required    bool
+	Error       string
+}
+
+type formOption struct {
+	Value    string
+	Selected bool
+}
+
+// WebForm is an http.Handler that serves the fields of a struct as an HTML form.
+// The form is only served to a browser that has opened the URL with the one-time token,
+// and the values submitted are validated the same as the values entered in a terminal.
+// Once valid values are submitted, they are assigned to the struct.
+type WebForm struct {
+	mu      sync.Mutex
+	orig    reflect.Value
+	opts    *Options
+	token   string
+	session string
+	csrf    string
+	done    chan struct{}
+}
+
+// NewWebForm creates a new WebForm for the pointer to a struct type.
+func NewWebForm(s interface{}, opts ...Option) (*WebForm, error) {
+	orig, err := rflct.IsStructPtr(s)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebForm{
+		orig:  orig,
+		opts:  newOptions(opts...),
+		token: token,
+		done:  make(chan struct{}),
+	}, nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Token returns the one-time token for opening the form.
+// The form is opened by the URL with the token as the token query parameter (i.e. http://127.0.0.1:8080/?token=...).
+func (w *WebForm) Token() string {
+	return w.token
+}
+
+// Done returns a channel that is closed once valid values are submitted and assigned to the struct.
+func (w *WebForm) Done() <-chan struct{} {
+	return w.done
+}
+
+// ServeHTTP serves the form.
+func (w *WebForm) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Prevent DNS rebinding attacks
+	if !isLoopbackHost(r.Host) {
+		http.Error(rw, "forbidden host", http.StatusForbidden)
+		return
+	}
+
+	if r.URL.Path != "/" {
+		http.NotFound(rw, r)
+		return
+	}
+
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("X-Frame-Options", "DENY")
+
+	// The one-time token is exchanged for a session
+	if token := r.URL.Query().Get("token"); token != "" {
+		if w.session != "" || subtle.ConstantTimeCompare([]byte(token), []byte(w.token)) != 1 {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if err := w.newSession(); err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(rw, &http.Cookie{
+			Name:     sessionCookie,
+			Value:    w.session,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		http.Redirect(rw, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	if c, err := r.Cookie(sessionCookie); err != nil || w.session == "" || subtle.ConstantTimeCompare([]byte(c.Value), []byte(w.session)) != 1 {
+		http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	select {
+	case <-w.done:
+		w.render(rw, http.StatusOK, formData{Saved: true}, nil, nil)
+		return
+	default:
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.render(rw, http.StatusOK, formData{}, nil, nil)
+
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.PostForm.Get("csrf")), []byte(w.csrf)) != 1 {
+			http.Error(rw, "invalid form", http.StatusForbidden)
+			return
+		}
+
+		errs, err := w.submit(r.PostForm)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if len(errs) > 0 {
+			w.render(rw, http.StatusUnprocessableEntity, formData{Invalid: true}, r.PostForm, errs)
+			return
+		}
+
+		close(w.done)
+		w.render(rw, http.StatusOK, formData{Saved: true}, nil, nil)
+
+	default:
+		rw.Header().Set("Allow", "GET, POST")
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (w *WebForm) newSession() error {
+	var err error
+
+	if w.session, err = randomToken(); err != nil {
+		return err
+	}
+
+	if w.csrf, err = randomToken(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isLoopbackHost determines whether the host of a request is a loopback address.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// render renders the form with the current values of the fields or the values submitted.
+// Errors are keyed by field name.
+func (w *WebForm) render(rw http.ResponseWriter, status int, data formData, submitted url.Values, errs map[string]string) {
+	data.Title = "Configuration"
+	data.CSRF = w.csrf
+
+	if !data.Saved {
+		entered := map[*section]bool{}
+
+		err := iterateOnFields("", w.orig, func(f fieldInfo) error {
+			if _, ok := lookupAnswer(f, w.opts); ok {
+				return nil
+			}
+
+			data.Fields = append(data.Fields, newFormField(w.orig, f, entered, submitted, errs[f.Name]))
+
+			return nil
+		})
+
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.WriteHeader(status)
+	_ = formTemplate.Execute(rw, data)
+}
+
+func newFormField(root reflect.Value, f fieldInfo, entered map[*section]bool, submitted url.Values, errMsg string) formField {
+	ff := formField{
+		Name:        f.Name,
+		Description: interpolate(root, f.Description),
+		Default:     f.Default,
+		Secret:      isMasked(f.Kind),
+		HasValue:    !f.Value.IsZero(),
+		Required:    f.Required,
+		Error:       errMsg,
+	}
+
+	// The title of a section is shown before its first field
+	if s := f.Section; s != nil && !entered[s] {
+		entered[s] = true
+		ff.Section = s.Title
+	}
+
+	if ff.Secret {
+		ff.Default = ""
+		return ff
+	}
+
+	if submitted != nil {
+		ff.Value = formValue(f, submitted)
+	} else if !f.Value.IsZero() {
+		ff.Value = formatValue(f)
+	}
+
+	switch {
+	case f.Kind == KindSelect || f.Kind == KindMultiSelect:
+		ff.Multiple = f.Kind == KindMultiSelect
+
+		selected := map[string]bool{}
+		if ff.Value == "" && submitted == nil && f.Default != "" {
+			ff.Value = f.Default
+		}
+		for _, val := range strings.Split(ff.Value, f.Sep) {
+			selected[val] = true
+		}
+
+		for _, opt := range f.Options {
+			ff.Options = append(ff.Options, formOption{Value: opt, Selected: selected[opt]})
+		}
+
+	case f.Value.Kind() == reflect.Bool:
+		ff.Checkbox = true
+
+	case f.Value.Kind() == reflect.Slice:
+		ff.Textarea = true
+		ff.Value = strings.ReplaceAll(ff.Value, f.Sep, "\n")
+
+	case f.Kind == KindEditor || f.Kind == KindMultiline:
+		ff.Textarea = true
+	}
+
+	return ff
+}
+
+// submit validates the values submitted and assigns them to the struct.
+// If any value is invalid, the struct is left unchanged and the errors are returned keyed by field name.
+func (w *WebForm) submit(form url.Values) (map[string]string, error) {
+	v := reflect.New(w.orig.Type()).Elem()
+	v.Set(w.orig)
+
+	errs := map[string]string{}
+
+	err := iterateOnFields("", v, func(f fieldInfo) error {
+		if ok, err := shouldAsk(v, f, w.opts); !ok || err != nil {
+			return err
+		}
+
+		if ok, err := answerField(f, w.opts); ok || err != nil {
+			return err
+		}
+
+		val := formValue(f, form)
+
+		// An empty secret keeps the current value
+		if val == "" && isMasked(f.Kind) && !f.Value.IsZero() {
+			return nil
+		}
+
+		if val == "" && f.Kind != KindMultiSelect {
+			if f.Default == "" && !f.Required {
+				f.Value.Set(reflect.Zero(f.Value.Type()))
+				return nil
+			}
+			val = f.Default
+		}
+
+		if val == "" && f.Required {
+			errs[f.Name] = w.opts.render(MessageValueRequired, f.Name)
+			return nil
+		}
+
+		nv, err := parseValue(f, val)
+		if err != nil {
+			var ie *inputError
+			if !errors.As(err, &ie) {
+				return err
+			}
+			errs[f.Name] = err.Error()
+			return nil
+		}
+
+		f.Value.Set(nv)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errs) == 0 {
+		w.orig.Set(v)
+	}
+
+	return errs, nil
+}
+
+// formValue returns the value submitted for a field.
+func formValue(f fieldInfo, form url.Values) string {
+	switch {
+	case f.Kind == KindMultiSelect:
+		return strings.Join(form[f.Name], f.Sep)
+
+	case f.Kind == KindSelect:
+		return form.Get(f.Name)
+
+	case f.Value.Kind() == reflect.Bool:
+		if form.Get(f.Name) == "true" {
+			return "true"
+		}
+		return "false"
+
+	case f.Value.Kind() == reflect.Slice:
+		items := []string{}
+		for _, line := range strings.Split(form.Get(f.Name), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				items = append(items, line)
+			}
+		}
+		return strings.Join(items, f.Sep)
+
+	case f.Kind == KindEditor || f.Kind == KindMultiline:
+		return strings.ReplaceAll(form.Get(f.Name), "\r\n", "\n")
+
+	default:
+		return form.Get(f.Name)
+	}
+}
+
+// AskHTTP serves the fields of a struct as an HTML form on a random port of the loopback interface.
+// Once the server is listening, ready is called with the URL of the form including the one-time token (i.e. for opening a browser).
+// It returns once valid values are submitted and assigned to the struct, or the context is cancelled.
+func AskHTTP(ctx context.Context, s interface{}, ready func(url string), opts ...Option) error {
+	w, err := NewWebForm(s, opts...)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           w,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	ready(fmt.Sprintf("http://%s/?token=%s", ln.Addr(), w.Token()))
+
+	select {
+	case <-w.Done():
+		// Let the response to the submission be written
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+
+	case err := <-errCh:
+		return err
+
+	case <-ctx.Done():
+		_ = srv.Close()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,274 @@
+package askit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type webConfig struct {
+	Name  string   `ask:"any, your name, required"`
+	Email string   `ask:"email, your email address"`
+	Token string   `ask:"secret, your access token"`
+	Env   string   `ask:"select, the environment" options:"dev|prod" default:"dev"`
+	Tags  []string `ask:"any, your tags"`
+	Debug bool     `ask:"any, enable debug logs"`
+}
+
+var csrfRE = regexp.MustCompile(`name="csrf" value="([0-9a-f]+)"`)
+
+// openWebForm exchanges the one-time token of a WebForm for a session cookie.
+func openWebForm(t *testing.T, w *WebForm) *http.Cookie {
+	req := httptest.NewRequest("GET", "http://127.0.0.1/?token="+w.Token(), nil)
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+
+	return cookies[0]
+}
+
+func TestNewWebForm(t *testing.T) {
+	_, err := NewWebForm(webConfig{})
+	assert.Error(t, err)
+
+	w1, err := NewWebForm(&webConfig{})
+	assert.NoError(t, err)
+	assert.Len(t, w1.Token(), 64)
+
+	w2, err := NewWebForm(&webConfig{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, w1.Token(), w2.Token())
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected bool
+	}{
+		{"127.0.0.1:8080", true},
+		{"[::1]:8080", true},
+		{"localhost:8080", true},
+		{"localhost", true},
+		{"example.com:8080", false},
+		{"10.0.0.1", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isLoopbackHost(tc.host))
+		})
+	}
+}
+
+func TestWebForm_Auth(t *testing.T) {
+	w, err := NewWebForm(&webConfig{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		cookie       bool
+		expectedCode int
+	}{
+		{"InvalidHost", "GET", "http://example.com/?token=" + w.Token(), false, http.StatusForbidden},
+		{"InvalidToken", "GET", "http://127.0.0.1/?token=invalid", false, http.StatusUnauthorized},
+		{"NoSession", "GET", "http://127.0.0.1/", false, http.StatusUnauthorized},
+		{"NotFound", "GET", "http://127.0.0.1/favicon.ico", true, http.StatusNotFound},
+		{"ReusedToken", "GET", "http://127.0.0.1/?token=" + w.Token(), false, http.StatusUnauthorized},
+		{"MethodNotAllowed", "PUT", "http://127.0.0.1/", true, http.StatusMethodNotAllowed},
+		{"NoCSRF", "POST", "http://127.0.0.1/", true, http.StatusForbidden},
+		{"Session", "GET", "http://127.0.0.1/", true, http.StatusOK},
+	}
+
+	cookie := openWebForm(t, w)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader("Name=jane"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.cookie {
+				req.AddCookie(cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			w.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWebForm_Form(t *testing.T) {
+	c := webConfig{Name: "Jane", Token: "s3cr3t", Tags: []string{"web", "api"}}
+	w, err := NewWebForm(&c)
+	assert.NoError(t, err)
+
+	cookie := openWebForm(t, w)
+
+	req := httptest.NewRequest("GET", "http://127.0.0.1/", nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `<input type="text" id="Name" name="Name" value="Jane">`)
+	assert.Contains(t, body, `<input type="password" id="Token" name="Token" autocomplete="off" placeholder="unchanged">`)
+	assert.Contains(t, body, `<option value="dev" selected>dev</option>`)
+	assert.Contains(t, body, `<textarea id="Tags" name="Tags" rows="5">web`+"\n"+`api</textarea>`)
+	assert.Contains(t, body, `<input type="checkbox" id="Debug" name="Debug" value="true">`)
+	assert.NotContains(t, body, "s3cr3t")
+}
+
+func TestWebForm_Submit(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		c := webConfig{Token: "s3cr3t"}
+		w, err := NewWebForm(&c)
+		assert.NoError(t, err)
+
+		cookie := openWebForm(t, w)
+
+		form := url.Values{
+			"csrf":  {w.csrf},
+			"Name":  {""},
+			"Email": {"jane"},
+			"Env":   {"stage"},
+		}
+
+		req := httptest.NewRequest("POST", "http://127.0.0.1/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "a value is required for Name")
+		assert.Contains(t, body, "invalid email address entered for Email: mail: missing &#39;@&#39; or angle-addr")
+		assert.Contains(t, body, "invalid option entered for Env: stage")
+		assert.Contains(t, body, `<input type="text" id="Email" name="Email" value="jane">`)
+		assert.Equal(t, webConfig{Token: "s3cr3t"}, c)
+
+		select {
+		case <-w.Done():
+			t.Fatal("form should not be done")
+		default:
+		}
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		c := webConfig{Email: "old@example.com", Token: "s3cr3t"}
+		w, err := NewWebForm(&c, WithAnswers(Answers{"Debug": true}))
+		assert.NoError(t, err)
+
+		cookie := openWebForm(t, w)
+
+		form := url.Values{
+			"csrf":  {w.csrf},
+			"Name":  {"Jane"},
+			"Email": {""},
+			"Token": {""},
+			"Env":   {"prod"},
+			"Tags":  {"web\r\n\r\n api \r\n"},
+		}
+
+		req := httptest.NewRequest("POST", "http://127.0.0.1/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The changes are saved.")
+		assert.Equal(t, webConfig{Name: "Jane", Token: "s3cr3t", Env: "prod", Tags: []string{"web", "api"}, Debug: true}, c)
+
+		select {
+		case <-w.Done():
+		default:
+			t.Fatal("form should be done")
+		}
+
+		// The form is not served again
+		req = httptest.NewRequest("GET", "http://127.0.0.1/", nil)
+		req.AddCookie(cookie)
+		rec = httptest.NewRecorder()
+		w.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "<form")
+	})
+}
+
+func TestAskHTTP(t *testing.T) {
+	t.Run("InvalidStruct", func(t *testing.T) {
+		err := AskHTTP(context.Background(), webConfig{}, func(string) {})
+		assert.Error(t, err)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := AskHTTP(ctx, &webConfig{}, func(string) {})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("Submitted", func(t *testing.T) {
+		jar, err := cookiejar.New(nil)
+		assert.NoError(t, err)
+		client := &http.Client{Jar: jar}
+
+		c := webConfig{}
+		errCh := make(chan error, 1)
+
+		ready := func(u string) {
+			go func() {
+				resp, err := client.Get(u)
+				if !assert.NoError(t, err) {
+					return
+				}
+				b, _ := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+
+				m := csrfRE.FindStringSubmatch(string(b))
+				if !assert.Len(t, m, 2) {
+					return
+				}
+
+				form := url.Values{"csrf": {m[1]}, "Name": {"Jane"}, "Debug": {"true"}}
+				resp, err = client.PostForm(resp.Request.URL.String(), form)
+				if assert.NoError(t, err) {
+					assert.Equal(t, http.StatusOK, resp.StatusCode)
+					_ = resp.Body.Close()
+				}
+			}()
+		}
+
+		go func() {
+			errCh <- AskHTTP(context.Background(), &c, ready)
+		}()
+
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+			assert.Equal(t, webConfig{Name: "Jane", Env: "dev", Debug: true}, c)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package flagit
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Ordering determines whether flags can appear after positional arguments.
+type Ordering int
+
+const (
+	// Interspersed allows flags to appear anywhere among positional arguments (GNU getopt style).
+	// For example, in "app file.txt --force", --force is parsed as a flag.
+	Interspersed Ordering = iota
+	// POSIX stops parsing flags at the first positional argument (POSIX and the built-in flag package style).
+	// For example, in "app file.txt --force", --force is returned unchanged as a positional argument.
+	// This is useful for wrapper commands that pass trailing flags through to child processes.
+	POSIX
+)
+
+// WithOrdering sets the ordering mode for flags and positional arguments.
+// The default ordering mode is Interspersed.
+func WithOrdering(ordering Ordering) Option {
+	return func(o *options) {
+		o.ordering = ordering
+	}
+}
+
+// argParser splits command-line arguments into flag values and positional arguments.
+type argParser struct {
+	fields   []fieldInfo
+	index    map[string]int
+	ordering Ordering
+	strict   bool
+}
+
+func newArgParser(fields []fieldInfo, ordering Ordering, strict bool) *argParser {
+	index := map[string]int{}
+	for i, f := range fields {
+		index[f.flag] = i
+		if f.alias != "" {
+			index[f.alias] = i
+		}
+	}
+
+	return &argParser{
+		fields:   fields,
+		index:    index,
+		ordering: ordering,
+		strict:   strict,
+	}
+}
+
+// isBool determines whether a flag can be used without a value.
+func (p *argParser) isBool(f fieldInfo) bool {
+	t := f.value.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Bool && !f.secret
+}
+
+// parse returns the values of flags keyed by the index of their fields and the positional arguments.
+// If a flag is repeated, its last value is used.
+// When an error occurs, parse continues with the next argument and returns the first error it encountered.
+func (p *argParser) parse(args []string) (map[int]string, []string, error) {
+	var firstErr error
+	values := map[int]string{}
+	positional := []string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// The terminator ends the flags
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+
+		if !flagArgRE.MatchString(arg) {
+			if p.ordering == POSIX {
+				positional = append(positional, args[i:]...)
+				break
+			}
+
+			positional = append(positional, arg)
+			continue
+		}
+
+		dashes := "-"
+		if strings.HasPrefix(arg, "--") {
+			dashes = "--"
+		}
+
+		name, val, hasVal := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+
+		// Unknown flags are passed through unchanged, since their values cannot be told apart from positional arguments
+		idx, ok := p.index[name]
+		if !ok {
+			if p.strict && firstErr == nil {
+				firstErr = p.unknownFlagError(dashes, name)
+			}
+			positional = append(positional, arg)
+			continue
+		}
+
+		f := p.fields[idx]
+
+		if !hasVal {
+			if next, ok := p.next(args, i); ok && (!p.isBool(f) || isBoolValue(next)) {
+				val = next
+				i++
+			} else if p.isBool(f) {
+				val = "true"
+			} else {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("flag needs an argument: %s%s", dashes, name)
+				}
+				continue
+			}
+		}
+
+		values[idx] = val
+	}
+
+	return values, positional, firstErr
+}
+
+// next returns the argument after the i-th argument if it can be a flag value.
+func (p *argParser) next(args []string, i int) (string, bool) {
+	if i+1 >= len(args) {
+		return "", false
+	}
+
+	if next := args[i+1]; next != "--" && !flagArgRE.MatchString(next) {
+		return next, true
+	}
+
+	return "", false
+}
+
+// unknownFlagError creates an error for an unknown flag with suggestions from the known flags (excluding aliases).
+func (p *argParser) unknownFlagError(dashes, name string) error {
+	flags := make([]string, len(p.fields))
+	for i, f := range p.fields {
+		flags[i] = f.flag
+	}
+
+	if suggestions := suggestFlags(name, flags); len(suggestions) > 0 {
+		return fmt.Errorf("unknown flag: %s%s (did you mean %s?)", dashes, name, joinFlags(dashes, suggestions))
+	}
+
+	return fmt.Errorf("unknown flag: %s%s", dashes, name)
+}
+
+func isBoolValue(val string) bool {
+	_, err := strconv.ParseBool(val)
+	return err == nil
+}
@@ -0,0 +1,83 @@
+package flagit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgParser(t *testing.T) {
+	var enabled bool
+	var number int
+	var text string
+	var names []string
+	var token string
+
+	fields := []fieldInfo{
+		{value: reflect.ValueOf(&enabled).Elem(), flag: "enabled"},
+		{value: reflect.ValueOf(&number).Elem(), flag: "number"},
+		{value: reflect.ValueOf(&text).Elem(), flag: "text", alias: "t"},
+		{value: reflect.ValueOf(&names).Elem(), flag: "name-list"},
+		{value: reflect.ValueOf(&token).Elem(), flag: "token", secret: true},
+	}
+
+	tests := []struct {
+		name               string
+		args               []string
+		ordering           Ordering
+		strict             bool
+		expectedValues     map[int]string
+		expectedPositional []string
+		expectedError      string
+	}{
+		{"NoArgs", []string{}, Interspersed, false, map[int]string{}, []string{}, ""},
+		{"Positional", []string{"app=invalid"}, Interspersed, false, map[int]string{}, []string{"app=invalid"}, ""},
+
+		{"Bool_SingleDash", []string{"-enabled"}, Interspersed, false, map[int]string{0: "true"}, []string{}, ""},
+		{"Bool_DoubleDash", []string{"--enabled"}, Interspersed, false, map[int]string{0: "true"}, []string{}, ""},
+		{"Bool_Equal", []string{"-enabled=false"}, Interspersed, false, map[int]string{0: "false"}, []string{}, ""},
+		{"Bool_Next", []string{"--enabled", "false"}, Interspersed, false, map[int]string{0: "false"}, []string{}, ""},
+		{"Bool_NextPositional", []string{"--enabled", "file"}, Interspersed, false, map[int]string{0: "true"}, []string{"file"}, ""},
+
+		{"Negative_Equal", []string{"-number=-10"}, Interspersed, false, map[int]string{1: "-10"}, []string{}, ""},
+		{"Negative_Next", []string{"--number", "-10"}, Interspersed, false, map[int]string{1: "-10"}, []string{}, ""},
+
+		{"Text_Equal", []string{"--text=content"}, Interspersed, false, map[int]string{2: "content"}, []string{}, ""},
+		{"Text_Next", []string{"-text", "content"}, Interspersed, false, map[int]string{2: "content"}, []string{}, ""},
+		{"Text_Alias", []string{"-t", "content"}, Interspersed, false, map[int]string{2: "content"}, []string{}, ""},
+		{"Text_Missing", []string{"-text", "-enabled"}, Interspersed, false, map[int]string{0: "true"}, []string{}, "flag needs an argument: -text"},
+
+		{"Multiple", []string{"--enabled", "--text", "content"}, Interspersed, false, map[int]string{0: "true", 2: "content"}, []string{}, ""},
+		{"List", []string{"--name-list", "alice,bob"}, Interspersed, false, map[int]string{3: "alice,bob"}, []string{}, ""},
+		{"Secret", []string{"--token", "true"}, Interspersed, false, map[int]string{4: "true"}, []string{}, ""},
+
+		{"Prefix", []string{"--texts", "content"}, Interspersed, false, map[int]string{}, []string{"--texts", "content"}, ""},
+		{"Unknown", []string{"--level", "debug", "f"}, Interspersed, false, map[int]string{}, []string{"--level", "debug", "f"}, ""},
+		{"Unknown_Equal", []string{"run", "--child-flag=1", "-t", "c"}, Interspersed, false, map[int]string{2: "c"}, []string{"run", "--child-flag=1"}, ""},
+		{"Unknown_BeforeFlag", []string{"--level", "--enabled", "file"}, Interspersed, false, map[int]string{0: "true"}, []string{"--level", "file"}, ""},
+		{"Unknown_POSIX", []string{"--level", "-t", "c", "run", "--force"}, POSIX, false, map[int]string{2: "c"}, []string{"--level", "run", "--force"}, ""},
+		{"Unknown_Strict", []string{"--txt", "content"}, Interspersed, true, map[int]string{}, []string{"--txt", "content"}, "unknown flag: --txt (did you mean --text?)"},
+
+		{"Interspersed", []string{"a", "--enabled", "b", "-t", "c"}, Interspersed, false, map[int]string{0: "true", 2: "c"}, []string{"a", "b"}, ""},
+		{"POSIX", []string{"--enabled", "a", "-t", "c"}, POSIX, false, map[int]string{0: "true"}, []string{"a", "-t", "c"}, ""},
+		{"Terminator", []string{"-t", "c", "--", "--enabled"}, POSIX, false, map[int]string{2: "c"}, []string{"--enabled"}, ""},
+		{"Stdin", []string{"-", "--enabled"}, Interspersed, false, map[int]string{0: "true"}, []string{"-"}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newArgParser(fields, tc.ordering, tc.strict)
+			values, positional, err := p.parse(tc.args)
+
+			assert.Equal(t, tc.expectedValues, values)
+			assert.Equal(t, tc.expectedPositional, positional)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package flagit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gardenbed/charm/internal/rflct"
+)
+
+// ParseFile accepts a path to a flag file and the pointer to a struct type.
+// For those struct fields that have the flag tag, it will read values from the flag file and parse them to the appropriate types.
+//
+// A flag file has one flag per line in the form of name=value or name value.
+// The leading dashes in flag names are optional, and a flag without a value is set to true.
+// Empty lines and lines starting with # are ignored.
+// Secret flags accept literal values in a flag file as well as env:NAME and file:PATH references.
+func ParseFile(path string, s interface{}, continueOnError bool) error {
+	v, err := rflct.IsStructPtr(s)
+	if err != nil {
+		return err
+	}
+
+	values, err := readFlagFile(path)
+	if err != nil {
+		return err
+	}
+
+	return iterateOnFields("", v, continueOnError, func(f fieldInfo) error {
+		if val, ok := values[f.flag]; ok {
+			if f.secret {
+				if val, err = resolveSecret(val, true); err != nil {
+					if continueOnError {
+						return nil
+					}
+					return fmt.Errorf("%s: %s: %s", path, f.flag, err)
+				}
+			}
+
+			if err := setValue(f.value, f.sep, val, f.secret); err != nil {
+				if continueOnError {
+					return nil
+				}
+				return fmt.Errorf("%s: %s", path, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func readFlagFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, val, ok := strings.Cut(line, "=")
+		if !ok {
+			name, val, ok = strings.Cut(line, " ")
+		}
+
+		name = strings.TrimLeft(strings.TrimSpace(name), "-")
+		val = strings.TrimSpace(val)
+
+		if !flagNameRE.MatchString(name) {
+			return nil, fmt.Errorf("%s:%d: invalid flag name: %s", path, n, name)
+		}
+
+		if !ok {
+			val = "true"
+		}
+
+		values[name] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,130 @@
+package flagit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "flags")
+	err := os.WriteFile(path, []byte(content), 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestParseFile(t *testing.T) {
+	type spec struct {
+		Verbose bool `flag:"verbose"`
+		Options struct {
+			Port     uint16        `flag:"port"`
+			LogLevel string        `flag:"log-level"`
+			Timeout  time.Duration `flag:"timeout"`
+		}
+		Names []string `flag:"names"`
+	}
+
+	tests := []struct {
+		name            string
+		content         string
+		s               interface{}
+		continueOnError bool
+		expectedError   string
+		expected        interface{}
+	}{
+		{
+			name:          "NonPointer",
+			content:       "",
+			s:             spec{},
+			expectedError: "non-pointer type: you should pass a pointer to a struct type",
+		},
+		{
+			name:          "InvalidFlagName",
+			content:       "log level=info",
+			s:             new(spec),
+			expectedError: "invalid flag name: log",
+		},
+		{
+			name:          "InvalidValue_StopOnError",
+			content:       "port=invalid",
+			s:             new(spec),
+			expectedError: `strconv.ParseUint: parsing "invalid": invalid syntax`,
+		},
+		{
+			name:            "InvalidValue_ContinueOnError",
+			content:         "port=invalid",
+			s:               new(spec),
+			continueOnError: true,
+			expected:        new(spec),
+		},
+		{
+			name: "Success",
+			content: `
+# Comments and empty lines are ignored
+--verbose
+-port 8080
+log-level = info
+timeout=1m
+names=alice,bob
+unknown=value
+`,
+			s: new(spec),
+			expected: &spec{
+				Verbose: true,
+				Options: struct {
+					Port     uint16        `flag:"port"`
+					LogLevel string        `flag:"log-level"`
+					Timeout  time.Duration `flag:"timeout"`
+				}{
+					Port:     8080,
+					LogLevel: "info",
+					Timeout:  time.Minute,
+				},
+				Names: []string{"alice", "bob"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeFile(t, tc.content)
+			err := ParseFile(path, tc.s, tc.continueOnError)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, tc.s)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+
+	t.Run("Secret", func(t *testing.T) {
+		s := new(struct {
+			Token string `flag:"token,the access token,secret"`
+			PIN   int    `flag:"pin,the pin code,secret"`
+		})
+
+		path := writeFile(t, "token=literal_token")
+		err := ParseFile(path, s, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "literal_token", s.Token)
+
+		path = writeFile(t, "pin=literal_pin")
+		err = ParseFile(path, s, false)
+		assert.EqualError(t, err, path+": invalid secret value")
+
+		path = writeFile(t, "token=env:TEST_MISSING")
+		err = ParseFile(path, s, false)
+		assert.EqualError(t, err, path+": token: environment variable not set: TEST_MISSING")
+	})
+
+	t.Run("NoFile", func(t *testing.T) {
+		err := ParseFile(filepath.Join(t.TempDir(), "missing"), new(spec), false)
+		assert.Error(t, err)
+	})
+}
@@ -4,31 +4,72 @@
 package flagit
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/gardenbed/charm/internal/rflct"
 )
 
 const (
-	flagTag = "flag"
-	sepTag  = "sep"
+	flagTag  = "flag"
+	aliasTag = "alias"
+	sepTag   = "sep"
+
+	secretOpt  = "secret"
+	secretMask = "*******"
 )
 
 var (
-	flagNameRE = regexp.MustCompile(`^[A-Za-z]([0-9A-Za-z-.]*[0-9A-Za-z])?$`)
-	flagArgRE  = regexp.MustCompile("^-{1,2}[A-Za-z]([0-9A-Za-z-.]*[0-9A-Za-z])?")
+	flagNameRE      = regexp.MustCompile(`^[A-Za-z]([0-9A-Za-z-.]*[0-9A-Za-z])?$`)
+	flagArgRE       = regexp.MustCompile("^-{1,2}[A-Za-z]([0-9A-Za-z-.]*[0-9A-Za-z])?")
+	undefinedFlagRE = regexp.MustCompile(`^flag provided but not defined: -(.+)$`)
 )
 
+// Option configures the behavior of Parse and ParseArgs.
+type Option func(*options)
+
+type options struct {
+	strict   bool
+	ordering Ordering
+}
+
+// Strict makes Parse reject the command-line flags that do not correspond to any field.
+// The error for an unknown flag includes suggestions for the closest known flags.
+// Strict has no effect when continueOnError is true, since errors are then ignored.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// Registrar is the interface for registering flags on a flag set.
+// The built-in *flag.FlagSet implements this interface.
+type Registrar interface {
+	Lookup(name string) *flag.Flag
+	Var(value flag.Value, name, usage string)
+	BoolVar(p *bool, name string, value bool, usage string)
+}
+
+// AliasRegistrar is an optional interface for flag sets that natively support aliases (shorthands) for flags.
+// If a Registrar does not implement this interface, aliases are registered as separate flags.
+type AliasRegistrar interface {
+	Registrar
+	VarAlias(value flag.Value, name, alias, usage string)
+	BoolVarAlias(p *bool, name, alias string, value bool, usage string)
+}
+
 // flagValue implements the flag.Value interface.
 type flagValue struct {
 	continueOnError bool
 	value           reflect.Value
 	sep             string
+	secret          bool
 }
 
 // String is called for getting and printing the default value.
@@ -37,8 +78,23 @@ func (v flagValue) String() string {
 	return ""
 }
 
+// Type returns the data type of the flag value.
+func (v flagValue) Type() string {
+	return v.value.Type().String()
+}
+
 func (v flagValue) Set(val string) error {
-	if _, err := rflct.SetValue(v.value, v.sep, val); err != nil {
+	if v.secret {
+		var err error
+		if val, err = resolveSecret(val, false); err != nil {
+			if v.continueOnError {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if err := setValue(v.value, v.sep, val, v.secret); err != nil {
 		if v.continueOnError {
 			return nil
 		}
@@ -50,20 +106,24 @@ func (v flagValue) Set(val string) error {
 
 // Register accepts a flag set and the pointer to a struct type.
 // For those struct fields that have the flag tag, it will register a flag on the given flag set.
+// For those struct fields that also have the alias tag, the alias will be registered as a shorthand for the flag.
 // The current values of the struct fields will be used as default values for the registered flags.
 // Once the Parse method on the flag set is called, the values will be read, parsed to the appropriate types, and assigned to the corresponding struct fields.
-func Register(fs *flag.FlagSet, s interface{}, continueOnError bool) error {
+// The error returned by the Parse method on the flag set can be passed to Suggest for adding suggestions for undefined flags.
+func Register(fs Registrar, s interface{}, continueOnError bool) error {
 	v, err := rflct.IsStructPtr(s)
 	if err != nil {
 		return err
 	}
 
 	return iterateOnFields("", v, continueOnError, func(f fieldInfo) error {
-		if fs.Lookup(f.flag) != nil {
-			if continueOnError {
-				return nil
+		for _, name := range []string{f.flag, f.alias} {
+			if name != "" && fs.Lookup(name) != nil {
+				if continueOnError {
+					return nil
+				}
+				return fmt.Errorf("flag already registered: %s", name)
 			}
-			return fmt.Errorf("flag already registered: %s", f.flag)
 		}
 
 		// Create usage string
@@ -73,35 +133,58 @@ func Register(fs *flag.FlagSet, s interface{}, continueOnError bool) error {
 			usage = f.help + "\n"
 		}
 
+		var defValue interface{} = f.value.Interface()
+		if f.secret && !f.value.IsZero() {
+			defValue = secretMask
+		}
+
 		switch f.value.Kind() {
 		case reflect.Slice:
 			usage += fmt.Sprintf("%-15s []%s\n%-15s %v\n%-15s %s",
 				"data type:", reflect.TypeOf(f.value.Interface()).Elem(),
-				"default value:", f.value.Interface(),
+				"default value:", defValue,
 				"separator:", f.sep,
 			)
 		case reflect.Struct:
 			usage += fmt.Sprintf("%-15s %s\n%-15s %+v",
 				"data type:", f.value.Type(),
-				"default value:", f.value.Interface(),
+				"default value:", defValue,
 			)
 		default:
 			usage += fmt.Sprintf("%-15s %s\n%-15s %v",
 				"data type:", f.value.Type(),
-				"default value:", f.value.Interface(),
+				"default value:", defValue,
 			)
 		}
 
+		if f.secret {
+			usage += fmt.Sprintf("\n%-15s %s", "secret:", "env:NAME or file:PATH")
+		}
+
 		// Register the flag
-		switch f.value.Kind() {
-		case reflect.Bool:
+		switch {
+		case f.value.Kind() == reflect.Bool && !f.secret:
 			// f.value.CanAddr() expected to be true
 			// f.value.Addr().Interface().(*bool) expected to be ok
 			ptr := f.value.Addr().Interface().(*bool)
-			fs.BoolVar(ptr, f.flag, f.value.Bool(), usage)
+			if ar, ok := fs.(AliasRegistrar); ok && f.alias != "" {
+				ar.BoolVarAlias(ptr, f.flag, f.alias, f.value.Bool(), usage)
+			} else {
+				fs.BoolVar(ptr, f.flag, f.value.Bool(), usage)
+				if f.alias != "" {
+					fs.BoolVar(ptr, f.alias, f.value.Bool(), "shorthand for -"+f.flag)
+				}
+			}
 		default:
-			fv := &flagValue{continueOnError, f.value, f.sep}
-			fs.Var(fv, f.flag, usage)
+			fv := &flagValue{continueOnError, f.value, f.sep, f.secret}
+			if ar, ok := fs.(AliasRegistrar); ok && f.alias != "" {
+				ar.VarAlias(fv, f.flag, f.alias, usage)
+			} else {
+				fs.Var(fv, f.flag, usage)
+				if f.alias != "" {
+					fs.Var(fv, f.alias, "shorthand for -"+f.flag)
+				}
+			}
 		}
 
 		return nil
@@ -111,32 +194,101 @@ func Register(fs *flag.FlagSet, s interface{}, continueOnError bool) error {
 // Parse accepts the pointer to a struct type.
 // For those struct fields that have the flag tag, it will read values from command-line flags and parse them to the appropriate types.
 // This method does not use the built-in flag package for parsing and reading the flags.
-func Parse(s interface{}, continueOnError bool) error {
+func Parse(s interface{}, continueOnError bool, opts ...Option) error {
+	_, err := ParseArgs(os.Args[1:], s, continueOnError, opts...)
+	return err
+}
+
+// ParseArgs accepts a list of command-line arguments (without the program name) and the pointer to a struct type.
+// For those struct fields that have the flag tag, it will read values from the arguments and parse them to the appropriate types.
+// It returns the positional arguments (non-flag arguments) in their original order.
+// Unknown flags are returned unchanged among the positional arguments, and they never take the next argument as their value.
+// Depending on the ordering mode (see WithOrdering), the positional arguments may include the flags that follow the first positional argument.
+func ParseArgs(args []string, s interface{}, continueOnError bool, opts ...Option) ([]string, error) {
 	v, err := rflct.IsStructPtr(s)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return iterateOnFields("", v, continueOnError, func(f fieldInfo) error {
-		if val := getFlagValue(f.flag); val != "" {
-			if _, err := rflct.SetValue(f.value, f.sep, val); err != nil {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	fields := []fieldInfo{}
+	err = iterateOnFields("", v, continueOnError, func(f fieldInfo) error {
+		fields = append(fields, f)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := newArgParser(fields, o.ordering, o.strict && !continueOnError)
+	values, positional, err := p.parse(args)
+	if err != nil && !continueOnError {
+		return nil, err
+	}
+
+	for i, f := range fields {
+		val, ok := values[i]
+		if !ok {
+			continue
+		}
+
+		if f.secret {
+			if val, err = resolveSecret(val, false); err != nil {
 				if continueOnError {
-					return nil
+					continue
 				}
-				return err
+				return nil, fmt.Errorf("%s: %s", f.flag, err)
+			}
+		}
+
+		if err := setValue(f.value, f.sep, val, f.secret); err != nil {
+			if continueOnError {
+				continue
 			}
+			return nil, err
 		}
+	}
+
+	return positional, nil
+}
 
+// Suggest decorates an undefined flag error returned by the Parse method of a flag set with suggestions for the closest registered flags.
+// Any other error is returned unchanged.
+func Suggest(fs *flag.FlagSet, err error) error {
+	if err == nil {
 		return nil
+	}
+
+	m := undefinedFlagRE.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	flags := []string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, f.Name)
 	})
+
+	if suggestions := suggestFlags(m[1], flags); len(suggestions) > 0 {
+		return fmt.Errorf("%s (did you mean %s?)", err, joinFlags("-", suggestions))
+	}
+
+	return err
 }
 
 type fieldInfo struct {
-	value reflect.Value
-	name  string
-	flag  string
-	help  string
-	sep   string
+	value  reflect.Value
+	name   string
+	flag   string
+	alias  string
+	help   string
+	sep    string
+	secret bool
 }
 
 func iterateOnFields(prefix string, vStruct reflect.Value, continueOnError bool, handle func(fieldInfo) error) error {
@@ -168,9 +320,15 @@ func iterateOnFields(prefix string, vStruct reflect.Value, continueOnError bool,
 		}
 
 		var flagName, flagHelp string
+		var secret bool
 		if strings.Contains(val, ",") {
 			subs := strings.Split(val, ",")
 			flagName, flagHelp = subs[0], subs[1]
+			for _, opt := range subs[2:] {
+				if strings.TrimSpace(opt) == secretOpt {
+					secret = true
+				}
+			}
 		} else {
 			flagName = val
 		}
@@ -186,6 +344,15 @@ func iterateOnFields(prefix string, vStruct reflect.Value, continueOnError bool,
 			return fmt.Errorf("invalid flag name: %s", flagName)
 		}
 
+		// `alias:"..."`
+		alias := f.Tag.Get(aliasTag)
+		if alias != "" && !flagNameRE.MatchString(alias) {
+			if continueOnError {
+				continue
+			}
+			return fmt.Errorf("invalid flag alias: %s", alias)
+		}
+
 		// `sep:"..."`
 		sep := f.Tag.Get(sepTag)
 		if sep == "" {
@@ -193,11 +360,13 @@ func iterateOnFields(prefix string, vStruct reflect.Value, continueOnError bool,
 		}
 
 		fi := fieldInfo{
-			value: v,
-			name:  f.Name,
-			flag:  flagName,
-			help:  flagHelp,
-			sep:   sep,
+			value:  v,
+			name:   f.Name,
+			flag:   flagName,
+			alias:  alias,
+			help:   flagHelp,
+			sep:    sep,
+			secret: secret,
 		}
 
 		if err := handle(fi); err != nil {
@@ -208,25 +377,112 @@ func iterateOnFields(prefix string, vStruct reflect.Value, continueOnError bool,
 	return nil
 }
 
-func getFlagValue(flag string) string {
-	flagRegex := regexp.MustCompile("-{1,2}" + flag)
+// setValue sets a value for a field.
+// For secret fields, the error does not include the value.
+func setValue(v reflect.Value, sep, val string, secret bool) error {
+	if _, err := rflct.SetValue(v, sep, val); err != nil {
+		if secret {
+			return errors.New("invalid secret value")
+		}
+		return err
+	}
 
-	for i, arg := range os.Args {
-		if flagRegex.MatchString(arg) {
-			if s := strings.Index(arg, "="); s > 0 {
-				return arg[s+1:]
-			}
+	return nil
+}
 
-			if i+1 < len(os.Args) {
-				if val := os.Args[i+1]; !flagArgRE.MatchString(val) {
-					return val
-				}
+// resolveSecret reads a secret value from an env:NAME or a file:PATH reference.
+// If literal is true, any other value is returned as is.
+func resolveSecret(val string, literal bool) (string, error) {
+	switch {
+	case strings.HasPrefix(val, "env:"):
+		name := strings.TrimPrefix(val, "env:")
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable not set: %s", name)
+		}
+		return secret, nil
+
+	case strings.HasPrefix(val, "file:"):
+		b, err := os.ReadFile(strings.TrimPrefix(val, "file:"))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+
+	case literal:
+		return val, nil
+
+	default:
+		return "", errors.New("secret values can only be read from env:NAME or file:PATH")
+	}
+}
+
+// suggestFlags returns up to three known flags that are the closest to an unknown flag.
+func suggestFlags(name string, flags []string) []string {
+	type candidate struct {
+		flag     string
+		distance int
+	}
+
+	maxDistance := len(name)/3 + 1
+	candidates := []candidate{}
+
+	for _, f := range flags {
+		if d := editDistance(name, f); d <= maxDistance {
+			candidates = append(candidates, candidate{f, d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, candidates[i].flag)
+	}
+
+	return suggestions
+}
+
+func joinFlags(dashes string, flags []string) string {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = dashes + f
+	}
+
+	return strings.Join(names, " or ")
+}
+
+// editDistance computes the optimal string alignment distance between two strings.
+// It counts the insertions, deletions, substitutions, and transpositions of adjacent characters.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
 			}
 
-			// For boolean flags
-			return "true"
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
 		}
 	}
 
-	return ""
+	return d[len(s)][len(t)]
 }
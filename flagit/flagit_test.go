@@ -3,8 +3,10 @@ package flagit
 import (
 	"errors"
 	"flag"
+	"io"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"testing"
@@ -761,40 +763,377 @@ func TestIterateOnFields(t *testing.T) {
 	}
 }
 
-func TestGetFlagValue(t *testing.T) {
+func TestRegister_Alias(t *testing.T) {
+	type spec struct {
+		Verbose bool   `flag:"verbose" alias:"v"`
+		Port    uint16 `flag:"port" alias:"p"`
+	}
+
+	invalid := struct {
+		Port uint16 `flag:"port" alias:"-p"`
+	}{}
+
+	t.Run("InvalidAlias", func(t *testing.T) {
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		err := Register(fs, &invalid, false)
+		assert.EqualError(t, err, "invalid flag alias: -p")
+	})
+
+	t.Run("AliasRegistered", func(t *testing.T) {
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		fs.String("p", "", "")
+		err := Register(fs, new(spec), false)
+		assert.EqualError(t, err, "flag already registered: p")
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		s := new(spec)
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		err := Register(fs, s, false)
+		assert.NoError(t, err)
+
+		err = fs.Parse([]string{"-v", "-p", "8080"})
+		assert.NoError(t, err)
+		assert.Equal(t, &spec{Verbose: true, Port: 8080}, s)
+		assert.Equal(t, "shorthand for -port", fs.Lookup("p").Usage)
+	})
+}
+
+func TestParse_Alias(t *testing.T) {
+	type spec struct {
+		Verbose bool   `flag:"verbose" alias:"v"`
+		Port    uint16 `flag:"port" alias:"p"`
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	os.Args = []string{"app", "-v", "-p", "8080"}
+
+	s := new(spec)
+	err := Parse(s, false, Strict())
+	assert.NoError(t, err)
+	assert.Equal(t, &spec{Verbose: true, Port: 8080}, s)
+}
+
+func TestRegister_Secret(t *testing.T) {
+	type spec struct {
+		Token string `flag:"token,the access token,secret"`
+		PIN   int    `flag:"pin,the pin code,secret"`
+	}
+
+	t.Setenv("TEST_TOKEN", "env_token")
+
+	path := filepath.Join(t.TempDir(), "pin")
+	err := os.WriteFile(path, []byte("1234\n"), 0600)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		args               []string
+		expectedParseError string
+		expected           *spec
+	}{
+		{
+			name:               "LiteralValue",
+			args:               []string{"-token", "literal_token"},
+			expectedParseError: "secret values can only be read from env:NAME or file:PATH",
+		},
+		{
+			name:               "InvalidValue",
+			args:               []string{"-pin", "env:TEST_TOKEN"},
+			expectedParseError: "invalid secret value",
+		},
+		{
+			name:     "OK",
+			args:     []string{"-token", "env:TEST_TOKEN", "-pin", "file:" + path},
+			expected: &spec{Token: "env_token", PIN: 1234},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &spec{Token: "default_token"}
+			fs := flag.NewFlagSet("app", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+
+			err := Register(fs, s, false)
+			assert.NoError(t, err)
+			assert.NotContains(t, fs.Lookup("token").Usage, "default_token")
+			assert.Contains(t, fs.Lookup("token").Usage, "*******")
+
+			err = fs.Parse(tc.args)
+
+			if tc.expectedParseError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, s)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedParseError)
+			}
+		})
+	}
+}
+
+func TestParse_Secret(t *testing.T) {
+	type spec struct {
+		Token string `flag:"token,the access token,secret"`
+		PIN   int    `flag:"pin,the pin code,secret"`
+	}
+
+	t.Setenv("TEST_TOKEN", "env_token")
+
+	tests := []struct {
+		name            string
+		args            []string
+		continueOnError bool
+		expectedError   string
+		expected        *spec
+	}{
+		{
+			name:          "LiteralValue",
+			args:          []string{"app", "-token", "literal_token"},
+			expectedError: "token: secret values can only be read from env:NAME or file:PATH",
+		},
+		{
+			name:          "MissingEnv",
+			args:          []string{"app", "-token", "env:TEST_MISSING"},
+			expectedError: "token: environment variable not set: TEST_MISSING",
+		},
+		{
+			name:          "InvalidValue",
+			args:          []string{"app", "-pin", "env:TEST_TOKEN"},
+			expectedError: "invalid secret value",
+		},
+		{
+			name:            "ContinueOnError",
+			args:            []string{"app", "-token", "literal_token", "-pin", "env:TEST_TOKEN"},
+			continueOnError: true,
+			expected:        &spec{},
+		},
+		{
+			name:     "OK",
+			args:     []string{"app", "-token", "env:TEST_TOKEN"},
+			expected: &spec{Token: "env_token"},
+		},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+
+			s := new(spec)
+			err := Parse(s, tc.continueOnError)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, s)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.NotContains(t, err.Error(), "literal_token")
+				assert.NotContains(t, err.Error(), "env_token")
+			}
+		})
+	}
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "env_token")
+
+	path := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(path, []byte("file_token\r\n"), 0600)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		val           string
+		literal       bool
+		expectedValue string
+		expectedError string
+	}{
+		{"Env", "env:TEST_TOKEN", false, "env_token", ""},
+		{"EnvMissing", "env:TEST_MISSING", false, "", "environment variable not set: TEST_MISSING"},
+		{"File", "file:" + path, false, "file_token", ""},
+		{"FileMissing", "file:" + path + ".missing", false, "", "no such file or directory"},
+		{"Literal", "literal_token", true, "literal_token", ""},
+		{"LiteralNotAllowed", "literal_token", false, "", "secret values can only be read from env:NAME or file:PATH"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := resolveSecret(tc.val, tc.literal)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedValue, val)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	type spec struct {
+		Force   bool   `flag:"force" alias:"f"`
+		Output  string `flag:"output" alias:"o"`
+		Retries int    `flag:"retries"`
+	}
+
 	tests := []struct {
-		args              []string
-		flag              string
-		expectedFlagValue string
+		name               string
+		args               []string
+		continueOnError    bool
+		opts               []Option
+		expectedError      string
+		expectedPositional []string
+		expected           *spec
 	}{
-		{[]string{"app=invalid"}, "invalid", ""},
+		{
+			name:               "Interspersed",
+			args:               []string{"file.txt", "--force", "-o", "out.txt", "dir"},
+			expectedPositional: []string{"file.txt", "dir"},
+			expected:           &spec{Force: true, Output: "out.txt"},
+		},
+		{
+			name:               "POSIX",
+			args:               []string{"--force", "child", "--output", "out.txt", "-f"},
+			opts:               []Option{WithOrdering(POSIX)},
+			expectedPositional: []string{"child", "--output", "out.txt", "-f"},
+			expected:           &spec{Force: true},
+		},
+		{
+			name:               "POSIX_Strict",
+			args:               []string{"-o=out.txt", "child", "--unknown"},
+			opts:               []Option{WithOrdering(POSIX), Strict()},
+			expectedPositional: []string{"child", "--unknown"},
+			expected:           &spec{Output: "out.txt"},
+		},
+		{
+			name:               "UnknownFlags",
+			args:               []string{"--verbose", "run", "--force", "file.txt", "--child-flag=1"},
+			expectedPositional: []string{"--verbose", "run", "file.txt", "--child-flag=1"},
+			expected:           &spec{Force: true},
+		},
+		{
+			name:               "Terminator",
+			args:               []string{"--force", "--", "--output", "out.txt"},
+			expectedPositional: []string{"--output", "out.txt"},
+			expected:           &spec{Force: true},
+		},
+		{
+			name:               "BoolWithValue",
+			args:               []string{"--force", "false", "file.txt"},
+			expectedPositional: []string{"file.txt"},
+			expected:           &spec{},
+		},
+		{
+			name:               "RepeatedFlag",
+			args:               []string{"--retries", "1", "--retries=2"},
+			expectedPositional: []string{},
+			expected:           &spec{Retries: 2},
+		},
+		{
+			name:          "MissingValue_StopOnError",
+			args:          []string{"--output"},
+			expectedError: "flag needs an argument: --output",
+		},
+		{
+			name:               "MissingValue_ContinueOnError",
+			args:               []string{"--output", "--force"},
+			continueOnError:    true,
+			expectedPositional: []string{},
+			expected:           &spec{Force: true},
+		},
+		{
+			name:          "InvalidValue",
+			args:          []string{"--retries", "many"},
+			expectedError: `strconv.ParseInt: parsing "many": invalid syntax`,
+		},
+	}
 
-		{[]string{"app", "-enabled"}, "enabled", "true"},
-		{[]string{"app", "--enabled"}, "enabled", "true"},
-		{[]string{"app", "-enabled=false"}, "enabled", "false"},
-		{[]string{"app", "--enabled=false"}, "enabled", "false"},
-		{[]string{"app", "-enabled", "false"}, "enabled", "false"},
-		{[]string{"app", "--enabled", "false"}, "enabled", "false"},
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := new(spec)
+			positional, err := ParseArgs(tc.args, s, tc.continueOnError, tc.opts...)
 
-		{[]string{"app", "-number=-10"}, "number", "-10"},
-		{[]string{"app", "--number=-10"}, "number", "-10"},
-		{[]string{"app", "-number", "-10"}, "number", "-10"},
-		{[]string{"app", "--number", "-10"}, "number", "-10"},
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPositional, positional)
+				assert.Equal(t, tc.expected, s)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, positional)
+			}
+		})
+	}
 
-		{[]string{"app", "-text=content"}, "text", "content"},
-		{[]string{"app", "--text=content"}, "text", "content"},
-		{[]string{"app", "-text", "content"}, "text", "content"},
-		{[]string{"app", "--text", "content"}, "text", "content"},
+	t.Run("NonPointer", func(t *testing.T) {
+		_, err := ParseArgs(nil, spec{}, false)
+		assert.EqualError(t, err, "non-pointer type: you should pass a pointer to a struct type")
+	})
 
-		{[]string{"app", "-enabled", "-text=content"}, "enabled", "true"},
-		{[]string{"app", "--enabled", "--text=content"}, "enabled", "true"},
-		{[]string{"app", "-enabled", "-text", "content"}, "enabled", "true"},
-		{[]string{"app", "--enabled", "--text", "content"}, "enabled", "true"},
+	t.Run("InvalidFlagName", func(t *testing.T) {
+		_, err := ParseArgs(nil, &struct {
+			LogLevel string `flag:"log level"`
+		}{}, false)
+		assert.EqualError(t, err, "invalid flag name: log level")
+	})
+}
 
-		{[]string{"app", "-name-list=alice,bob"}, "name-list", "alice,bob"},
-		{[]string{"app", "--name-list=alice,bob"}, "name-list", "alice,bob"},
-		{[]string{"app", "-name-list", "alice,bob"}, "name-list", "alice,bob"},
-		{[]string{"app", "--name-list", "alice,bob"}, "name-list", "alice,bob"},
+func TestParse_Strict(t *testing.T) {
+	type spec struct {
+		Verbose bool `flag:"verbose"`
+		Options struct {
+			Port     uint16 `flag:"port"`
+			LogLevel string `flag:"log-level"`
+		}
+	}
+
+	tests := []struct {
+		name            string
+		args            []string
+		continueOnError bool
+		expectedError   string
+	}{
+		{
+			name:            "KnownFlags",
+			args:            []string{"app", "-verbose", "--port", "8080", "--log-level=info", "--", "--other"},
+			continueOnError: false,
+			expectedError:   "",
+		},
+		{
+			name:            "UnknownFlag_WithSuggestion",
+			args:            []string{"app", "--prot", "8080"},
+			continueOnError: false,
+			expectedError:   "unknown flag: --prot (did you mean --port?)",
+		},
+		{
+			name:            "UnknownFlag_WithValue",
+			args:            []string{"app", "-log-levl=info"},
+			continueOnError: false,
+			expectedError:   "unknown flag: -log-levl (did you mean -log-level?)",
+		},
+		{
+			name:            "UnknownFlag_WithoutSuggestion",
+			args:            []string{"app", "--config"},
+			continueOnError: false,
+			expectedError:   "unknown flag: --config",
+		},
+		{
+			name:            "UnknownFlag_ContinueOnError",
+			args:            []string{"app", "--prot", "8080"},
+			continueOnError: true,
+			expectedError:   "",
+		},
 	}
 
 	origArgs := os.Args
@@ -803,9 +1142,103 @@ func TestGetFlagValue(t *testing.T) {
 	}()
 
 	for _, tc := range tests {
-		os.Args = tc.args
-		flagValue := getFlagValue(tc.flag)
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+
+			err := Parse(new(spec), tc.continueOnError, Strict())
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Uint("port", 0, "")
+	fs.String("log-level", "", "")
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError string
+	}{
+		{
+			name:          "NoError",
+			err:           nil,
+			expectedError: "",
+		},
+		{
+			name:          "OtherError",
+			err:           errors.New(`invalid value "foo" for flag -port: parse error`),
+			expectedError: `invalid value "foo" for flag -port: parse error`,
+		},
+		{
+			name:          "WithSuggestion",
+			err:           fs.Parse([]string{"-prot", "8080"}),
+			expectedError: "flag provided but not defined: -prot (did you mean -port?)",
+		},
+		{
+			name:          "WithoutSuggestion",
+			err:           fs.Parse([]string{"--config"}),
+			expectedError: "flag provided but not defined: -config",
+		},
+	}
 
-		assert.Equal(t, tc.expectedFlagValue, flagValue)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Suggest(fs, tc.err)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestSuggestFlags(t *testing.T) {
+	flags := []string{"port", "sort", "log-level", "verbose"}
+
+	tests := []struct {
+		name                string
+		flag                string
+		expectedSuggestions []string
+	}{
+		{"Transposition", "prot", []string{"port", "sort"}},
+		{"Deletion", "verbos", []string{"verbose"}},
+		{"Insertion", "log-levels", []string{"log-level"}},
+		{"NoMatch", "config", []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSuggestions, suggestFlags(tc.flag, flags))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b             string
+		expectedDistance int
+	}{
+		{"", "", 0},
+		{"port", "", 4},
+		{"", "port", 4},
+		{"port", "port", 0},
+		{"prot", "port", 1},
+		{"port", "sort", 1},
+		{"verbos", "verbose", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expectedDistance, editDistance(tc.a, tc.b))
 	}
 }
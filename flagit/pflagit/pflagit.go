@@ -0,0 +1,97 @@
+// Package pflagit bridges the flagit package to the github.com/spf13/pflag package.
+// You can register the fields of a struct tagged with the flag tag on a pflag.FlagSet (used by cobra as well).
+package pflagit
+
+import (
+	"flag"
+	"unicode/utf8"
+
+	"github.com/spf13/pflag"
+
+	"github.com/gardenbed/charm/flagit"
+)
+
+// value adapts a flag.Value to the pflag.Value interface.
+type value struct {
+	flag.Value
+}
+
+// Type returns the data type of the flag value.
+func (v value) Type() string {
+	if t, ok := v.Value.(interface{ Type() string }); ok {
+		return t.Type()
+	}
+
+	return "value"
+}
+
+// Registrar adapts a pflag.FlagSet to the flagit.AliasRegistrar interface.
+// Aliases consisting of a single character are registered as shorthands.
+type Registrar struct {
+	fs *pflag.FlagSet
+}
+
+// NewRegistrar creates a new registrar for a pflag.FlagSet.
+func NewRegistrar(fs *pflag.FlagSet) *Registrar {
+	return &Registrar{
+		fs: fs,
+	}
+}
+
+// Lookup returns the flag registered with the given name or shorthand.
+// It returns nil if no such flag is registered.
+func (r *Registrar) Lookup(name string) *flag.Flag {
+	f := r.fs.Lookup(name)
+	if f == nil && utf8.RuneCountInString(name) == 1 {
+		f = r.fs.ShorthandLookup(name)
+	}
+
+	if f == nil {
+		return nil
+	}
+
+	return &flag.Flag{
+		Name:     f.Name,
+		Usage:    f.Usage,
+		Value:    f.Value,
+		DefValue: f.DefValue,
+	}
+}
+
+// Var registers a flag with the given name and usage string.
+func (r *Registrar) Var(v flag.Value, name, usage string) {
+	r.fs.Var(value{v}, name, usage)
+}
+
+// BoolVar registers a boolean flag with the given name, default value, and usage string.
+func (r *Registrar) BoolVar(p *bool, name string, val bool, usage string) {
+	r.fs.BoolVar(p, name, val, usage)
+}
+
+// VarAlias registers a flag with the given name, alias, and usage string.
+func (r *Registrar) VarAlias(v flag.Value, name, alias, usage string) {
+	if utf8.RuneCountInString(alias) == 1 {
+		r.fs.VarP(value{v}, name, alias, usage)
+		return
+	}
+
+	r.fs.Var(value{v}, name, usage)
+	r.fs.Var(value{v}, alias, "shorthand for --"+name)
+}
+
+// BoolVarAlias registers a boolean flag with the given name, alias, default value, and usage string.
+func (r *Registrar) BoolVarAlias(p *bool, name, alias string, val bool, usage string) {
+	if utf8.RuneCountInString(alias) == 1 {
+		r.fs.BoolVarP(p, name, alias, val, usage)
+		return
+	}
+
+	r.fs.BoolVar(p, name, val, usage)
+	r.fs.BoolVar(p, alias, val, "shorthand for --"+name)
+}
+
+// Register accepts a pflag.FlagSet and the pointer to a struct type.
+// It works the same as flagit.Register, but registers the flags on a pflag.FlagSet.
+func Register(fs *pflag.FlagSet, s interface{}, continueOnError bool) error {
+	return flagit.Register(NewRegistrar(fs), s, continueOnError)
+}
@@ -0,0 +1,133 @@
+package pflagit
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+type spec struct {
+	Verbose bool `flag:"verbose,enable verbose logs" alias:"v"`
+	Options struct {
+		Port     uint16        `flag:"port,the port number" alias:"p"`
+		LogLevel string        `flag:"log-level,the logging level" alias:"ll"`
+		Timeout  time.Duration `flag:"timeout,the request timeout"`
+		Debug    bool          `flag:"debug,enable debug mode" alias:"dbg"`
+	}
+}
+
+func TestValue(t *testing.T) {
+	var b bool
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.BoolVar(&b, "bool", false, "")
+
+	v := value{fs.Lookup("bool").Value}
+	assert.Equal(t, "value", v.Type())
+}
+
+func TestRegistrar(t *testing.T) {
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	r := NewRegistrar(fs)
+
+	var b bool
+	r.BoolVarAlias(&b, "verbose", "v", false, "enable verbose logs")
+
+	assert.Nil(t, r.Lookup("port"))
+
+	f := r.Lookup("verbose")
+	assert.NotNil(t, f)
+	assert.Equal(t, "verbose", f.Name)
+	assert.Equal(t, "enable verbose logs", f.Usage)
+	assert.Equal(t, "false", f.DefValue)
+
+	f = r.Lookup("v")
+	assert.NotNil(t, f)
+	assert.Equal(t, "verbose", f.Name)
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name               string
+		fs                 *pflag.FlagSet
+		s                  interface{}
+		args               []string
+		expectedError      error
+		expectedParseError string
+		expected           *spec
+	}{
+		{
+			name:          "NonPointer",
+			fs:            pflag.NewFlagSet("app", pflag.ContinueOnError),
+			s:             spec{},
+			expectedError: errors.New("non-pointer type: you should pass a pointer to a struct type"),
+		},
+		{
+			name:               "InvalidValue",
+			fs:                 pflag.NewFlagSet("app", pflag.ContinueOnError),
+			s:                  new(spec),
+			args:               []string{"-p", "invalid"},
+			expectedParseError: `invalid argument "invalid" for "-p, --port" flag: strconv.ParseUint: parsing "invalid": invalid syntax`,
+		},
+		{
+			name: "LongFlags",
+			fs:   pflag.NewFlagSet("app", pflag.ContinueOnError),
+			s:    new(spec),
+			args: []string{"--verbose", "--port", "8080", "--log-level=info", "--timeout", "1m", "--debug"},
+			expected: &spec{
+				Verbose: true,
+				Options: struct {
+					Port     uint16        `flag:"port,the port number" alias:"p"`
+					LogLevel string        `flag:"log-level,the logging level" alias:"ll"`
+					Timeout  time.Duration `flag:"timeout,the request timeout"`
+					Debug    bool          `flag:"debug,enable debug mode" alias:"dbg"`
+				}{
+					Port:     8080,
+					LogLevel: "info",
+					Timeout:  time.Minute,
+					Debug:    true,
+				},
+			},
+		},
+		{
+			name: "Shorthands",
+			fs:   pflag.NewFlagSet("app", pflag.ContinueOnError),
+			s:    new(spec),
+			args: []string{"-v", "-p", "8080", "--ll=info", "--dbg"},
+			expected: &spec{
+				Verbose: true,
+				Options: struct {
+					Port     uint16        `flag:"port,the port number" alias:"p"`
+					LogLevel string        `flag:"log-level,the logging level" alias:"ll"`
+					Timeout  time.Duration `flag:"timeout,the request timeout"`
+					Debug    bool          `flag:"debug,enable debug mode" alias:"dbg"`
+				}{
+					Port:     8080,
+					LogLevel: "info",
+					Debug:    true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Register(tc.fs, tc.s, false)
+			assert.Equal(t, tc.expectedError, err)
+
+			if tc.expectedError == nil {
+				err := tc.fs.Parse(tc.args)
+
+				if tc.expectedParseError == "" {
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expected, tc.s)
+				} else {
+					assert.EqualError(t, err, tc.expectedParseError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package flagit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"time"
+
+	"github.com/gardenbed/charm/internal/rflct"
+)
+
+// Reloader reloads a flag file into a fresh copy of a struct.
+// A reload is triggered when the flag file changes or when the process receives a SIGHUP signal.
+// Changes are detected by polling the modification time and the size of the flag file.
+// On platforms without SIGHUP (js), reloads are only triggered by polling.
+//
+// Values in the flag file take precedence over the base configuration passed to Run.
+// If the base configuration already has higher-priority layers applied (e.g. command-line flags),
+// use Overlay to re-apply them on every reloaded copy.
+type Reloader struct {
+	// Path is the path to the flag file (see ParseFile).
+	Path string
+	// Interval is the polling interval for detecting changes to the flag file.
+	// If zero, the flag file is not watched and reloads are only triggered by SIGHUP.
+	Interval time.Duration
+	// Overlay is an optional function called with every reloaded copy of the struct right after parsing the flag file.
+	// It can be used for re-applying the configuration layers that take precedence over the flag file (i.e. command-line flags).
+	Overlay func(interface{}) error
+	// Validate is an optional function for validating a reloaded copy of the struct.
+	// An invalid copy is rejected and passed to neither Reload nor the running configuration.
+	Validate func(interface{}) error
+	// Reload is called with the pointer to a reloaded and validated copy of the struct.
+	Reload func(interface{})
+	// Error is an optional function for reporting failed reloads.
+	// While the flag file cannot be accessed, the same error is only reported once.
+	Error func(error)
+}
+
+// Run accepts the pointer to a struct type holding the base configuration.
+// On every reload, a copy of the base configuration is made and the flag file is parsed into the copy.
+// The base configuration itself is never modified.
+// Run blocks until the context is cancelled.
+func (r *Reloader) Run(ctx context.Context, s interface{}) error {
+	if _, err := rflct.IsStructPtr(s); err != nil {
+		return err
+	}
+
+	if r.Reload == nil {
+		return errors.New("no reload function provided")
+	}
+
+	base := reflect.ValueOf(s).Elem()
+	stat, _ := os.Stat(r.Path)
+	var statErr error
+
+	sigCh := make(chan os.Signal, 1)
+	notifyReload(sigCh)
+	defer signal.Stop(sigCh)
+
+	var tickCh <-chan time.Time
+	if r.Interval > 0 {
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-sigCh:
+			stat, _ = os.Stat(r.Path)
+			r.reload(base)
+
+		case <-tickCh:
+			newStat, err := os.Stat(r.Path)
+			if err != nil {
+				// Only report when the error changes, so a missing file is not reported on every tick.
+				if statErr == nil || statErr.Error() != err.Error() {
+					r.error(err)
+				}
+				stat, statErr = nil, err
+				continue
+			}
+			statErr = nil
+
+			if stat != nil && newStat.ModTime().Equal(stat.ModTime()) && newStat.Size() == stat.Size() {
+				continue
+			}
+
+			stat = newStat
+			r.reload(base)
+		}
+	}
+}
+
+func (r *Reloader) reload(base reflect.Value) {
+	// SetValue replaces pointers and slices rather than mutating them,
+	// so a shallow copy does not share any state that a reload can change.
+	v := reflect.New(base.Type())
+	v.Elem().Set(base)
+	s := v.Interface()
+
+	if err := ParseFile(r.Path, s, false); err != nil {
+		r.error(err)
+		return
+	}
+
+	if r.Overlay != nil {
+		if err := r.Overlay(s); err != nil {
+			r.error(err)
+			return
+		}
+	}
+
+	if r.Validate != nil {
+		if err := r.Validate(s); err != nil {
+			r.error(err)
+			return
+		}
+	}
+
+	r.Reload(s)
+}
+
+func (r *Reloader) error(err error) {
+	if r.Error != nil {
+		r.Error(err)
+	}
+}
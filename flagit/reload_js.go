@@ -0,0 +1,6 @@
+package flagit
+
+import "os"
+
+// notifyReload is a no-op, since there is no SIGHUP signal on js.
+func notifyReload(chan<- os.Signal) {}
@@ -0,0 +1,14 @@
+//go:build !js
+
+package flagit
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload relays the SIGHUP signals to a channel for triggering reloads.
+func notifyReload(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGHUP)
+}
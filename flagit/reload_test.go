@@ -0,0 +1,172 @@
+package flagit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloader(t *testing.T) {
+	type spec struct {
+		Port     uint16 `flag:"port"`
+		LogLevel string `flag:"log-level"`
+	}
+
+	t.Run("NonPointer", func(t *testing.T) {
+		r := &Reloader{Reload: func(interface{}) {}}
+		err := r.Run(context.Background(), spec{})
+		assert.EqualError(t, err, "non-pointer type: you should pass a pointer to a struct type")
+	})
+
+	t.Run("NoReloadFunc", func(t *testing.T) {
+		r := &Reloader{}
+		err := r.Run(context.Background(), new(spec))
+		assert.EqualError(t, err, "no reload function provided")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		path := writeFile(t, "port=8080")
+		base := &spec{Port: 80, LogLevel: "info"}
+
+		reloads := make(chan *spec, 10)
+		errs := make(chan error, 10)
+
+		r := &Reloader{
+			Path:     path,
+			Interval: 10 * time.Millisecond,
+			Validate: func(s interface{}) error {
+				if s.(*spec).Port < 1024 {
+					return errors.New("invalid port")
+				}
+				return nil
+			},
+			Reload: func(s interface{}) {
+				reloads <- s.(*spec)
+			},
+			Error: func(err error) {
+				errs <- err
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- r.Run(ctx, base)
+		}()
+
+		// Invalid reload
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, os.WriteFile(path, []byte("port=100"), 0600))
+
+		select {
+		case err := <-errs:
+			assert.EqualError(t, err, "invalid port")
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the reload error")
+		}
+
+		// Valid reload
+		assert.NoError(t, os.WriteFile(path, []byte("port=9090\nlog-level=debug"), 0600))
+
+		select {
+		case s := <-reloads:
+			assert.Equal(t, &spec{Port: 9090, LogLevel: "debug"}, s)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the reload")
+		}
+
+		// The base configuration must not be modified
+		assert.Equal(t, &spec{Port: 80, LogLevel: "info"}, base)
+
+		cancel()
+		assert.Equal(t, context.Canceled, <-done)
+	})
+
+	t.Run("Overlay", func(t *testing.T) {
+		path := writeFile(t, "port=8080\nlog-level=debug")
+		base := &spec{Port: 9090, LogLevel: "info"}
+
+		reloads := make(chan *spec, 10)
+
+		r := &Reloader{
+			Path:     path,
+			Interval: 10 * time.Millisecond,
+			Overlay: func(s interface{}) error {
+				_, err := ParseArgs([]string{"-port", "9090"}, s, false)
+				return err
+			},
+			Reload: func(s interface{}) {
+				reloads <- s.(*spec)
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- r.Run(ctx, base)
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, os.WriteFile(path, []byte("port=8081\nlog-level=warn"), 0600))
+
+		select {
+		case s := <-reloads:
+			assert.Equal(t, &spec{Port: 9090, LogLevel: "warn"}, s)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the reload")
+		}
+
+		cancel()
+		assert.Equal(t, context.Canceled, <-done)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		path := writeFile(t, "port=8080")
+		base := &spec{Port: 80, LogLevel: "info"}
+
+		reloads := make(chan *spec, 10)
+		errs := make(chan error, 100)
+
+		r := &Reloader{
+			Path:     path,
+			Interval: 10 * time.Millisecond,
+			Reload: func(s interface{}) {
+				reloads <- s.(*spec)
+			},
+			Error: func(err error) {
+				errs <- err
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- r.Run(ctx, base)
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, os.Remove(path))
+
+		// The missing file is only reported once
+		time.Sleep(100 * time.Millisecond)
+		assert.Len(t, errs, 1)
+		assert.True(t, os.IsNotExist(<-errs))
+
+		// The file is reloaded once it reappears
+		assert.NoError(t, os.WriteFile(path, []byte("port=8080"), 0600))
+
+		select {
+		case s := <-reloads:
+			assert.Equal(t, &spec{Port: 8080, LogLevel: "info"}, s)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the reload")
+		}
+
+		cancel()
+		assert.Equal(t, context.Canceled, <-done)
+	})
+}
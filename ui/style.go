@@ -103,6 +103,16 @@ func BgTrueColor(r, g, b int) Style {
 	return Style{Bg256, ANSICode(2), ANSICode(r), ANSICode(g), ANSICode(b)}
 }
 
+// Sprintf formats according to a format specifier and returns the resulting string in the style.
+// If the style is empty, the string is returned without any ANSI codes.
+func (s Style) Sprintf(format string, a ...interface{}) string {
+	if len(s) == 0 {
+		return fmt.Sprintf(format, a...)
+	}
+
+	return s.sprintf(format, a...)
+}
+
 func (s Style) sprintf(format string, a ...interface{}) string {
 	const escape = "\x1b"
 
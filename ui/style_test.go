@@ -165,3 +165,30 @@ func TestStyle_sprintf(t *testing.T) {
 		})
 	}
 }
+
+func TestStyle_Sprintf(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              Style
+		expectedString string
+	}{
+		{
+			name:           "Empty",
+			s:              nil,
+			expectedString: "Hello, World!",
+		},
+		{
+			name:           "Bold",
+			s:              Style{Bold},
+			expectedString: "\x1b[1mHello, World!\x1b[0m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.s.Sprintf("Hello, %s!", "World")
+
+			assert.Equal(t, tc.expectedString, s)
+		})
+	}
+}